@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -27,6 +28,7 @@ type EnrichmentResult struct {
 	Matches    map[string]releasenotes.Match
 	Features   []releasenotes.Feature
 	Properties []string
+	Notes      *releasenotes.ReleaseNotes
 }
 
 // printMatchingDebugInfo outputs detailed matching information
@@ -46,6 +48,9 @@ func printMatchingDebugInfo(result *EnrichmentResult) {
 	fmt.Println(strings.Repeat("-", 80))
 	for i, feature := range result.Features {
 		fmt.Printf("\n[%d] %s\n", i+1, feature.Title)
+		if feature.Version != "" {
+			fmt.Printf("    Release: %s\n", feature.Version)
+		}
 		// Show truncated description
 		desc := feature.Description
 		if len(desc) > 150 {
@@ -66,12 +71,24 @@ func printMatchingDebugInfo(result *EnrichmentResult) {
 			featureMatches[match.Feature.Title] = append(featureMatches[match.Feature.Title], match)
 		}
 
+		featureNames := make([]string, 0, len(featureMatches))
 		for featureName, matches := range featureMatches {
+			featureNames = append(featureNames, featureName)
+			sort.Slice(matches, func(i, j int) bool { return matches[i].Property < matches[j].Property })
+		}
+		sort.Strings(featureNames)
+
+		for _, featureName := range featureNames {
+			matches := featureMatches[featureName]
 			fmt.Printf("\n📦 %s (%d properties)\n", featureName, len(matches))
 			for _, match := range matches {
 				fmt.Printf("   ✓ %s\n", match.Property)
 				fmt.Printf("      Match Type: %s\n", match.MatchType)
 				fmt.Printf("      Confidence: %.2f\n", match.Confidence)
+				fmt.Printf("      Why: %s\n", match.Explanation)
+				for _, candidate := range match.Candidates[1:] {
+					fmt.Printf("      Runner-up: %s (%.2f)\n", candidate.Feature.Title, candidate.Score)
+				}
 			}
 		}
 	}
@@ -88,7 +105,7 @@ func printMatchingDebugInfo(result *EnrichmentResult) {
 		}
 		fmt.Println("\nTip: Unmatched properties may indicate:")
 		fmt.Println("  - Property names don't appear in release notes")
-		fmt.Println("  - Keyword matching threshold (0.5) not met")
+		fmt.Printf("  - Keyword score threshold (%.2f) not met\n", releasenotes.MatchThreshold)
 		fmt.Println("  - Property is an internal implementation detail")
 	}
 
@@ -109,80 +126,99 @@ func findUnmatchedProperties(properties []string, matches map[string]releasenote
 // enrichWithReleaseNotes orchestrates the release notes enrichment process
 func enrichWithReleaseNotes(
 	comparison *compare.ComparisonResults,
+	oldVersion string,
 	newVersion string,
 	productID string,
-	config releasenotes.ProductConfig,
+	registry *releasenotes.Registry,
 	urlOverride string,
+	availableVersions []string,
+	fetcher *releasenotes.Fetcher,
 ) (*EnrichmentResult, error) {
 
-	// Resolve URL
-	var url string
+	// Resolve the release notes pages covering every release in the upgrade
+	var pages []releasenotes.Page
 	var err error
 	if urlOverride != "" {
-		url = urlOverride
+		page := releasenotes.Page{URL: urlOverride, Line: releasenotes.VersionLine(newVersion)}
+		if product, ok := registry.Product(productID); ok && !product.Parser.IsZero() {
+			page.Profile = &product.Parser
+		}
+		pages = []releasenotes.Page{page}
 	} else {
-		url, err = config.ResolveURL(productID, newVersion)
+		pages, err = registry.PagesForRange(productID, availableVersions, oldVersion, newVersion)
 		if err != nil {
 			return nil, fmt.Errorf("failed to resolve URL: %w", err)
 		}
 	}
 
-	// Fetch release notes
-	fetcher := releasenotes.NewFetcher()
-	html, err := fetcher.Fetch(url)
+	// Fetch and parse release notes, keeping features released after the old version
+	notes, err := releasenotes.FetchReleaseNotes(fetcher, pages, oldVersion, newVersion)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch release notes: %w", err)
 	}
+	features := notes.Features()
 
-	// Parse features
-	features, err := releasenotes.ParseHTML(html)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse HTML: %w", err)
+	// Collect property names of every change type, with their form labels and descriptions as matching context
+	var properties, addedOrChanged, removed []string
+	context := make(map[string]string)
+	collect := func(changes []compare.ComparisonResult, names *[]string) {
+		for _, change := range changes {
+			properties = append(properties, change.PropertyName)
+			*names = append(*names, change.PropertyName)
+			context[change.PropertyName] = strings.Join([]string{change.FormLabel, change.PropertyLabel, change.PropertyDescription}, " ")
+		}
 	}
-
-	// Collect property names
-	var properties []string
-	for _, change := range comparison.Added {
-		properties = append(properties, change.PropertyName)
+	collect(comparison.Added, &addedOrChanged)
+	collect(comparison.Changed, &addedOrChanged)
+	collect(comparison.Removed, &removed)
+
+	// Match properties to features; removals are usually explained by a breaking change or deprecation notice
+	matcher := releasenotes.NewMatcher(features).WithContext(context)
+	matches := matcher.Match(addedOrChanged)
+	for property, match := range matcher.MatchPreferring(removed, releasenotes.SectionBreakingChanges, releasenotes.SectionDeprecations) {
+		matches[property] = match
 	}
 
-	// Match properties to features
-	matcher := releasenotes.NewMatcher(features)
-	matches := matcher.Match(properties)
-
 	return &EnrichmentResult{
 		Matches:    matches,
 		Features:   features,
 		Properties: properties,
+		Notes:      notes,
 	}, nil
 }
 
 func main() {
 	// Define flags
-	oldTile := flag.String("old-tile", "", "Path to old .pivotal file (required)")
-	newTile := flag.String("new-tile", "", "Path to new .pivotal file (required)")
+	oldTile := flag.String("old-tile", "", "Old tile: .pivotal file, metadata YAML, extracted tile directory, or s3://bucket/key")
+	newTile := flag.String("new-tile", "", "New tile: .pivotal file, metadata YAML, extracted tile directory, or s3://bucket/key")
 	productGUID := flag.String("product-guid", "", "Product GUID in Ops Manager (optional)")
 	opsManagerURL := flag.String("ops-manager-url", "", "Ops Manager URL (optional)")
 	username := flag.String("username", "", "Ops Manager username (optional)")
 	password := flag.String("password", "", "Ops Manager password (optional)")
 	skipSSL := flag.Bool("skip-ssl-validation", false, "Skip SSL certificate validation")
 	reportFormat := flag.String("format", "text", "Output format: text or json")
+	verifyReleaseFiles := flag.Bool("verify-release-files", false, "Confirm BOSH release files listed in metadata exist in the new tile")
 
 	// Pivnet-related flags
-	productSlug := flag.String("product-slug", "", "Pivnet product slug (e.g., 'cf')")
-	oldVersion := flag.String("old-version", "", "Old release version")
-	newVersion := flag.String("new-version", "", "New release version")
+	productSlug := flag.String("product-slug", "", "Pivnet product slug or a product name from the product config (e.g., 'cf')")
+	oldVersion := flag.String("old-version", "", "Old release version, range (e.g. '6.0', '~10.2', '>=6.0.20 <6.1'), 'latest' or 'latest-lts'")
+	newVersion := flag.String("new-version", "", "New release version, range (e.g. '6.0', '~10.2', '>=6.0.20 <6.1'), 'latest' or 'latest-lts'")
 	pivnetToken := flag.String("pivnet-token", "", "Pivnet API token (or use PIVNET_TOKEN env var)")
 	productFile := flag.String("product-file", "", "Specific product file name (optional)")
 	acceptEULA := flag.Bool("accept-eula", false, "Accept EULAs without prompting")
 	nonInteractive := flag.Bool("non-interactive", false, "Fail instead of prompting for input")
 	cacheDir := flag.String("cache-dir", "", "Download cache directory (default: ~/.tile-diff/cache)")
+	metadataOnly := flag.Bool("metadata-only", false, "Fetch only tile metadata via HTTP range requests instead of downloading whole tiles")
 
 	// Release notes enrichment flags
 	skipReleaseNotes := flag.Bool("skip-release-notes", false, "Skip release notes enrichment")
 	releaseNotesURL := flag.String("release-notes-url", "", "Override release notes URL")
+	releaseNotesDir := flag.String("release-notes-dir", "", "Read release notes from a directory of saved pages or a mirror base URL instead of the documentation site")
 	productID := flag.String("product-id", "", "Override product ID detection")
-	productConfig := flag.String("product-config", "configs/products.yaml", "Path to product config file")
+	offline := flag.Bool("offline", false, "Use only release notes pages already cached in ~/.tile-diff/release-notes")
+	releaseNotesTTL := flag.Duration("release-notes-cache-ttl", releasenotes.DefaultCacheTTL, "How long cached release notes pages are used before revalidating")
+	productConfig := flag.String("product-config", "", "Product registry file layered over the built-in registry and ~/.tile-diff/products.yaml")
+	printProductConfig := flag.Bool("print-product-config", false, "Print the effective product registry and exit")
 	verbose := flag.Bool("verbose", false, "Enable verbose output")
 	debugMatching := flag.Bool("debug-matching", false, "Show detailed property-to-feature matching information")
 	showVersion := flag.Bool("version", false, "Show version information")
@@ -195,9 +231,40 @@ func main() {
 		os.Exit(0)
 	}
 
+	// Load the product registry once: the built-in one, then ~/.tile-diff/products.yaml and --product-config
+	// An invalid user registry stops the run rather than silently dropping its overrides
+	registry, err := releasenotes.LoadEffectiveRegistry(*productConfig)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: Could not load product config: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Handle print-product-config flag
+	if *printProductConfig {
+		output, err := registry.Marshal()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Print(string(output))
+		os.Exit(0)
+	}
+
 	// Track if we're in JSON mode to suppress non-JSON output
 	jsonMode := *reportFormat == "json"
 
+	// Resolve product names such as "tas" to the Pivnet slug, Ops Manager product type and default product file
+	slug, productType, defaultProductFile := *productSlug, *productSlug, ""
+	if product, ok := registry.Identify(*productSlug); ok {
+		if product.PivnetSlug != "" {
+			slug = product.PivnetSlug
+		}
+		if product.ProductType != "" {
+			productType = product.ProductType
+		}
+		defaultProductFile = product.ProductFile
+	}
+
 	// Detect mode: local files or Pivnet download
 	usingLocalFiles := *oldTile != "" || *newTile != ""
 	usingPivnetDownload := (*oldVersion != "" || *newVersion != "") && *productSlug != ""
@@ -206,7 +273,7 @@ func main() {
 	if usingLocalFiles && usingPivnetDownload {
 		fmt.Fprintf(os.Stderr, "Error: Cannot mix local tile files with Pivnet download versions\n")
 		fmt.Fprintf(os.Stderr, "Use either:\n")
-		fmt.Fprintf(os.Stderr, "  --old-tile + --new-tile (local files)\n")
+		fmt.Fprintf(os.Stderr, "  --old-tile + --new-tile (.pivotal files, metadata YAML, extracted directories, or s3:// URIs)\n")
 		fmt.Fprintf(os.Stderr, "OR\n")
 		fmt.Fprintf(os.Stderr, "  --product-slug + --old-version + --new-version (Pivnet download)\n")
 		fmt.Fprintf(os.Stderr, "\nNote: --product-slug can be used with local files for auto-detecting product GUID\n\n")
@@ -220,7 +287,15 @@ func main() {
 		os.Exit(1)
 	}
 
+	if *metadataOnly && !usingPivnetDownload {
+		fmt.Fprintf(os.Stderr, "Error: --metadata-only requires Pivnet download mode (--product-slug, --old-version, --new-version)\n\n")
+		flag.Usage()
+		os.Exit(1)
+	}
+
 	var oldTilePath, newTilePath string
+	var oldProductFile, newProductFile *pivnet.ProductFile
+	var releaseVersions []string
 
 	if usingPivnetDownload {
 		// Validate Pivnet flags
@@ -254,7 +329,7 @@ func main() {
 			fmt.Printf("tile-diff - Ops Manager Product Tile Comparison\n")
 			fmt.Printf("================================================\n\n")
 			fmt.Printf("Mode: Pivnet Download\n")
-			fmt.Printf("Product: %s\n", *productSlug)
+			fmt.Printf("Product: %s\n", slug)
 			fmt.Printf("Versions: %s -> %s\n\n", *oldVersion, *newVersion)
 		}
 
@@ -265,6 +340,17 @@ func main() {
 			os.Exit(1)
 		}
 
+		// Every release in the upgrade range contributes release notes
+		if !*skipReleaseNotes {
+			if releases, err := client.GetReleases(slug); err == nil {
+				for _, release := range releases {
+					releaseVersions = append(releaseVersions, release.Version)
+				}
+			} else if *verbose {
+				fmt.Fprintf(os.Stderr, "Warning: Could not list releases for release notes: %v\n", err)
+			}
+		}
+
 		// Setup paths
 		home, _ := os.UserHomeDir()
 		cacheDirectory := *cacheDir
@@ -291,14 +377,15 @@ func main() {
 			fmt.Printf("Resolving and downloading old tile (%s)...\n", *oldVersion)
 		}
 		oldOpts := pivnet.DownloadOptions{
-			ProductSlug:    *productSlug,
+			ProductSlug:    slug,
 			Version:        *oldVersion,
 			ProductFile:    *productFile,
+			DefaultFile:    defaultProductFile,
 			AcceptEULA:     *acceptEULA,
 			NonInteractive: *nonInteractive,
 			CacheDir:       cacheDirectory,
 		}
-		oldTilePath, err = downloader.Download(oldOpts)
+		oldTilePath, oldProductFile, err = fetchTile(downloader, oldOpts, *metadataOnly)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error downloading old tile: %v\n", err)
 			os.Exit(1)
@@ -312,14 +399,15 @@ func main() {
 			fmt.Printf("Resolving and downloading new tile (%s)...\n", *newVersion)
 		}
 		newOpts := pivnet.DownloadOptions{
-			ProductSlug:    *productSlug,
+			ProductSlug:    slug,
 			Version:        *newVersion,
 			ProductFile:    *productFile,
+			DefaultFile:    defaultProductFile,
 			AcceptEULA:     *acceptEULA,
 			NonInteractive: *nonInteractive,
 			CacheDir:       cacheDirectory,
 		}
-		newTilePath, err = downloader.Download(newOpts)
+		newTilePath, newProductFile, err = fetchTile(downloader, newOpts, *metadataOnly)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error downloading new tile: %v\n", err)
 			os.Exit(1)
@@ -348,7 +436,7 @@ func main() {
 	if !jsonMode {
 		fmt.Printf("Loading old tile: %s\n", oldTilePath)
 	}
-	oldMetadata, err := metadata.LoadFromFile(oldTilePath)
+	oldMetadata, err := metadata.Load(oldTilePath)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error loading old tile: %v\n", err)
 		os.Exit(1)
@@ -361,7 +449,7 @@ func main() {
 	if !jsonMode {
 		fmt.Printf("Loading new tile: %s\n", newTilePath)
 	}
-	newMetadata, err := metadata.LoadFromFile(newTilePath)
+	newMetadata, err := metadata.Load(newTilePath)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error loading new tile: %v\n", err)
 		os.Exit(1)
@@ -376,9 +464,29 @@ func main() {
 	}
 	results := compare.CompareMetadata(oldMetadata, newMetadata, true)
 
+	// Release files and config templates are only available from local .pivotal files
+	newIsArchive := isTileArchive(newTilePath)
+	bothAreArchives := isTileArchive(oldTilePath) && newIsArchive
+
+	// Cross-reference changed BOSH releases with the files bundled in the new tile
+	if *verifyReleaseFiles && !newIsArchive {
+		fmt.Fprintf(os.Stderr, "Warning: --verify-release-files requires the full new tile and is skipped for %s\n", newTilePath)
+	} else if *verifyReleaseFiles {
+		releaseFiles, err := metadata.ListReleaseFiles(newTilePath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: Could not list release files in new tile: %v\n", err)
+		} else {
+			compare.CheckReleaseFiles(results.Releases, releaseFiles)
+		}
+	}
+
 	// Extract and compare configuration templates using om config-template
 	var configComparison *om.ConfigComparison
-	if err := om.CheckOMAvailable(); err == nil {
+	if !bothAreArchives {
+		if *verbose {
+			fmt.Fprintf(os.Stderr, "Note: config template comparison requires local .pivotal files - skipped\n")
+		}
+	} else if err := om.CheckOMAvailable(); err == nil {
 		if !jsonMode {
 			fmt.Printf("\nExtracting configuration templates...\n")
 		}
@@ -417,42 +525,61 @@ func main() {
 			fmt.Printf("\nAttempting release notes enrichment...\n")
 		}
 
-		// Load product config
-		config, err := releasenotes.LoadProductConfig(*productConfig)
+		// Determine product ID and version from the new tile's metadata, falling back to the product slug
+		prodID := *productID
+		if prodID == "" {
+			prodID = registry.IdentifyProduct(map[string]interface{}{"name": newMetadata.Name})
+			if _, ok := registry.Product(prodID); !ok {
+				if product, ok := registry.Identify(*productSlug); ok {
+					prodID = product.ID
+				}
+			}
+		}
+		tileVersion := newMetadata.ProductVersion
+		if tileVersion == "" {
+			tileVersion = *newVersion
+		}
+		previousVersion := oldMetadata.ProductVersion
+		if previousVersion == "" {
+			previousVersion = *oldVersion
+		}
+
+		// Local tiles have no release list yet; list the product's Pivnet releases when a token is available,
+		// otherwise say which version lines between the two tiles will be missing
+		if len(releaseVersions) == 0 && *releaseNotesURL == "" && prodID != "" {
+			releaseVersions = listReleaseVersions(registry, prodID, *pivnetToken, *offline, *verbose)
+			if len(releaseVersions) == 0 {
+				if skipped := releasenotes.SkippedLines(previousVersion, tileVersion); len(skipped) > 0 {
+					fmt.Fprintf(os.Stderr, "Warning: No Pivnet release list for %s; release notes for %s are not included. Set PIVNET_TOKEN to include every release in the upgrade.\n",
+						prodID, strings.Join(skipped, ", "))
+				}
+			}
+		}
+
+		// Try to enrich
+		if (prodID == "" || tileVersion == "") && *releaseNotesURL == "" {
+			err = fmt.Errorf("could not determine product ID and version from tile metadata; use --product-id or --release-notes-url")
+		} else {
+			fetcher := releasenotes.NewCachingFetcher(releasenotes.NewPageCache(releasenotes.DefaultCacheDir(), *releaseNotesTTL), *offline)
+			if *releaseNotesDir != "" {
+				fetcher = releasenotes.NewSourceFetcher(releasenotes.OpenSource(*releaseNotesDir, fetcher))
+			}
+			enrichmentResult, err = enrichWithReleaseNotes(results, previousVersion, tileVersion, prodID, registry, *releaseNotesURL, releaseVersions, fetcher)
+		}
 		if err != nil {
 			if *verbose {
-				fmt.Fprintf(os.Stderr, "Warning: Could not load product config: %v\n", err)
+				fmt.Fprintf(os.Stderr, "Warning: Release notes enrichment failed: %v\n", err)
 				fmt.Fprintf(os.Stderr, "Continuing with standard report...\n\n")
 			}
 		} else {
-			// Determine product ID
-			prodID := *productID
-			if prodID == "" {
-				// Default to "cf" for now - could be enhanced to auto-detect from metadata
-				prodID = "cf"
+			matches = enrichmentResult.Matches
+			if *verbose {
+				fmt.Printf("Enriched with %d property matches\n\n", len(matches))
 			}
 
-			// Extract version from new tile filename
-			// For now, use a simple approach - this could be enhanced
-			newVersion := "10.2.5" // Default version - should be extracted from metadata or filename
-
-			// Try to enrich
-			enrichmentResult, err = enrichWithReleaseNotes(results, newVersion, prodID, config, *releaseNotesURL)
-			if err != nil {
-				if *verbose {
-					fmt.Fprintf(os.Stderr, "Warning: Release notes enrichment failed: %v\n", err)
-					fmt.Fprintf(os.Stderr, "Continuing with standard report...\n\n")
-				}
-			} else {
-				matches = enrichmentResult.Matches
-				if *verbose {
-					fmt.Printf("Enriched with %d property matches\n\n", len(matches))
-				}
-
-				// Print debug matching info if requested
-				if *debugMatching {
-					printMatchingDebugInfo(enrichmentResult)
-				}
+			// Print debug matching info if requested
+			if *debugMatching {
+				printMatchingDebugInfo(enrichmentResult)
 			}
 		}
 	}
@@ -543,14 +670,16 @@ func main() {
 		}
 	}
 
+	hasOpsManagerCredentials := *opsManagerURL != "" && *username != "" && *password != ""
+
 	// Auto-detect product GUID if not provided but credentials are available
 	effectiveProductGUID := *productGUID
-	if effectiveProductGUID == "" && *productSlug != "" && *opsManagerURL != "" && *username != "" && *password != "" {
+	if effectiveProductGUID == "" && *productSlug != "" && hasOpsManagerCredentials {
 		if !jsonMode {
-			fmt.Printf("\nAuto-detecting product GUID for '%s'...\n", *productSlug)
+			fmt.Printf("\nAuto-detecting product GUID for '%s'...\n", productType)
 		}
 		client := api.NewClient(*opsManagerURL, *username, *password, *skipSSL)
-		detectedGUID, err := client.FindProductGUID(*productSlug)
+		detectedGUID, err := client.FindProductGUID(productType)
 		if err != nil {
 			if *verbose {
 				fmt.Fprintf(os.Stderr, "Warning: Could not auto-detect product GUID: %v\n", err)
@@ -564,8 +693,14 @@ func main() {
 		}
 	}
 
+	var categorized *report.CategorizedChanges
+	header := report.ReportHeader{
+		Old: describeTile(oldTilePath, oldProductFile, oldMetadata, *verbose),
+		New: describeTile(newTilePath, newProductFile, newMetadata, *verbose),
+	}
+
 	// Load current configuration if API credentials provided
-	if effectiveProductGUID != "" && *opsManagerURL != "" && *username != "" && *password != "" {
+	if effectiveProductGUID != "" && hasOpsManagerCredentials {
 		if !jsonMode {
 			fmt.Printf("\nQuerying Ops Manager API...\n")
 		}
@@ -607,25 +742,7 @@ func main() {
 		filtered := report.FilterRelevantChanges(results, currentConfig)
 
 		// Categorize changes
-		categorized := report.CategorizeChanges(filtered)
-
-		// Generate report based on format
-		fmt.Println()
-		switch *reportFormat {
-		case "json":
-			jsonReport := report.GenerateJSONReport(categorized, *oldTile, *newTile)
-			fmt.Println(jsonReport)
-		default:
-			// Use enriched report if we have matches
-			var textReport string
-			if len(matches) > 0 {
-				enriched := report.EnrichChanges(categorized, matches)
-				textReport = report.GenerateTextReportWithFeatures(enriched, *oldTile, *newTile)
-			} else {
-				textReport = report.GenerateTextReport(categorized, *oldTile, *newTile)
-			}
-			fmt.Println(textReport)
-		}
+		categorized = report.CategorizeChanges(filtered)
 	} else {
 		// Generate formatted report without Ops Manager API
 		if !jsonMode {
@@ -640,26 +757,172 @@ func main() {
 		}
 
 		// Categorize all changes (without filtering by current config)
-		categorized := report.CategorizeChanges(results)
-
-		// Generate report based on format
-		fmt.Println()
-		switch *reportFormat {
-		case "json":
-			jsonReport := report.GenerateJSONReport(categorized, oldTilePath, newTilePath)
-			fmt.Println(jsonReport)
-		default:
-			// Use enriched report if we have matches
-			var textReport string
-			if len(matches) > 0 {
-				enriched := report.EnrichChanges(categorized, matches)
-				textReport = report.GenerateTextReportWithFeatures(enriched, oldTilePath, newTilePath)
-			} else {
-				textReport = report.GenerateTextReport(categorized, oldTilePath, newTilePath)
-			}
-			fmt.Println(textReport)
+		categorized = report.CategorizeChanges(results)
+	}
+
+	// Breaking changes, known issues, and security fixes apply to the upgrade even when no property matched
+	if enrichmentResult != nil {
+		categorized.UpgradeNotes = enrichmentResult.Notes.Notes(report.UpgradeNoteKinds...)
+		categorized.SecurityFixes = releasenotes.ExtractSecurityFixes(enrichmentResult.Notes)
+
+		// Compare release notes component tables across the upgrade and against the new tile's BOSH releases
+		categorized.NotesComponents = enrichmentResult.Notes.ComponentChanges()
+		tileVersion := newMetadata.ProductVersion
+		if tileVersion == "" {
+			tileVersion = *newVersion
+		}
+		categorized.ComponentMismatches = releasenotes.ReconcileComponents(enrichmentResult.Notes.ComponentsFor(tileVersion), newMetadata.Releases)
+	}
+
+	// Check deployment prerequisites against Ops Manager
+	if hasOpsManagerCredentials {
+		client := api.NewClient(*opsManagerURL, *username, *password, *skipSSL)
+		checkStemcells(client, newMetadata, categorized, *verbose)
+		checkDependencies(client, newMetadata, categorized, *verbose)
+		if effectiveProductGUID != "" {
+			checkErrands(client, effectiveProductGUID, categorized, *verbose)
+		}
+	}
+
+	// Generate report based on format
+	fmt.Println()
+	switch *reportFormat {
+	case "json":
+		jsonReport := report.GenerateJSONReport(categorized, header)
+		fmt.Println(jsonReport)
+	default:
+		// Use enriched report if we have matches
+		var textReport string
+		if len(matches) > 0 {
+			enriched := report.EnrichChanges(categorized, matches)
+			textReport = report.GenerateTextReportWithFeatures(enriched, header)
+		} else {
+			textReport = report.GenerateTextReport(categorized, header)
 		}
+		fmt.Println(textReport)
+	}
+}
+
+// listReleaseVersions lists a product's Pivnet release versions, or nothing when there is no token,
+// the product has no Pivnet slug, or the listing fails
+func listReleaseVersions(registry *releasenotes.Registry, productID, token string, offline, verbose bool) []string {
+	if token == "" {
+		token = os.Getenv("PIVNET_TOKEN")
+	}
+	product, ok := registry.Product(productID)
+	if token == "" || offline || !ok || product.PivnetSlug == "" {
+		return nil
+	}
+
+	client, err := pivnet.NewClient(token)
+	if err != nil {
+		if verbose {
+			fmt.Fprintf(os.Stderr, "Warning: Could not create Pivnet client for release notes: %v\n", err)
+		}
+		return nil
+	}
+	releases, err := client.GetReleases(product.PivnetSlug)
+	if err != nil {
+		if verbose {
+			fmt.Fprintf(os.Stderr, "Warning: Could not list releases for release notes: %v\n", err)
+		}
+		return nil
+	}
+
+	versions := make([]string, 0, len(releases))
+	for _, release := range releases {
+		versions = append(versions, release.Version)
+	}
+	return versions
+}
+
+// describeTile identifies a loaded tile for the report header. Tiles fetched from Pivnet are named by
+// their product file and published SHA256 rather than the cached file they were loaded from.
+func describeTile(location string, productFile *pivnet.ProductFile, tile *metadata.TileMetadata, verbose bool) report.TileInfo {
+	var publishedSHA256 string
+	if productFile != nil {
+		publishedSHA256 = productFile.SHA256
+	}
+
+	// Only hash the local file when Pivnet did not publish a checksum; tiles can be several GB
+	info, err := report.NewTileInfo(location, tile, publishedSHA256)
+	if err != nil && verbose {
+		fmt.Fprintf(os.Stderr, "Warning: Could not checksum %s: %v\n", location, err)
 	}
+	if productFile != nil {
+		info.FileName = productFile.Name
+	}
+	return info
+}
+
+// isTileArchive reports whether path is a local .pivotal ZIP rather than another metadata source
+func isTileArchive(path string) bool {
+	kind, err := metadata.DetectSource(path)
+	return err == nil && kind == metadata.SourceZip
+}
+
+// fetchTile downloads the tile, or only its metadata when metadataOnly is set, returning the local path
+// and the product file it came from
+func fetchTile(downloader *pivnet.Downloader, opts pivnet.DownloadOptions, metadataOnly bool) (string, *pivnet.ProductFile, error) {
+	if metadataOnly {
+		return downloader.FetchMetadata(opts)
+	}
+	return downloader.Download(opts)
+}
+
+// checkStemcells adds required actions for stemcells the new tile needs that are not uploaded
+func checkStemcells(client *api.Client, newMetadata *metadata.TileMetadata, categorized *report.CategorizedChanges, verbose bool) {
+	associations, err := client.GetStemcellAssociations()
+	if err != nil {
+		if verbose {
+			fmt.Fprintf(os.Stderr, "Warning: Could not check stemcell availability: %v\n", err)
+		}
+		return
+	}
+
+	missing := report.CheckStemcellAvailability(newMetadata.Stemcells(), associations.StemcellLibrary)
+	categorized.RequiredActions = append(categorized.RequiredActions, missing...)
+}
+
+// checkDependencies adds required actions for product dependencies the deployed foundation does not satisfy
+func checkDependencies(client *api.Client, newMetadata *metadata.TileMetadata, categorized *report.CategorizedChanges, verbose bool) {
+	if len(newMetadata.RequiresProductVersions) == 0 {
+		return
+	}
+
+	deployed, err := client.GetDeployedProducts()
+	if err != nil {
+		if verbose {
+			fmt.Fprintf(os.Stderr, "Warning: Could not check product dependencies: %v\n", err)
+		}
+		return
+	}
+
+	opsManagerVersion, err := client.GetOpsManagerVersion()
+	if err != nil && verbose {
+		fmt.Fprintf(os.Stderr, "Warning: Could not determine Ops Manager version: %v\n", err)
+	}
+
+	unmet := report.CheckDependencies(newMetadata.RequiresProductVersions, deployed, opsManagerVersion)
+	categorized.RequiredActions = append(categorized.RequiredActions, unmet...)
+}
+
+// checkErrands adds warnings for errands that will start running by default after the upgrade
+func checkErrands(client *api.Client, productGUID string, categorized *report.CategorizedChanges, verbose bool) {
+	if len(categorized.Errands) == 0 {
+		return
+	}
+
+	errands, err := client.GetErrands(productGUID)
+	if err != nil {
+		if verbose {
+			fmt.Fprintf(os.Stderr, "Warning: Could not check errand configuration: %v\n", err)
+		}
+		return
+	}
+
+	enabled := report.CheckErrands(categorized.Errands, errands)
+	categorized.Warnings = append(categorized.Warnings, enabled...)
 }
 
 func countConfigurable(blueprints []metadata.PropertyBlueprint) int {
@@ -0,0 +1,10 @@
+// ABOUTME: Embeds the default product registry into the tile-diff binary.
+// ABOUTME: User registries in ~/.tile-diff/products.yaml and --product-config are layered on top.
+package configs
+
+import _ "embed"
+
+// Products is the built-in products.yaml registry
+//
+//go:embed products.yaml
+var Products []byte
@@ -0,0 +1,59 @@
+// ABOUTME: Random tile metadata shared by property-based tests across packages.
+// ABOUTME: Builds overlapping old and new tiles with properties, forms, releases, and credentials.
+package tiletest
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+
+	"github.com/malston/tile-diff/pkg/metadata"
+)
+
+// RandomTilePair builds two tile versions from a seed, with overlapping random properties spread
+// across forms, BOSH releases, and certificate credentials
+func RandomTilePair(seed int64) (*metadata.TileMetadata, *metadata.TileMetadata) {
+	r := rand.New(rand.NewSource(seed))
+	return randomTile(r), randomTile(r)
+}
+
+// randomTile builds one tile version from r
+func randomTile(r *rand.Rand) *metadata.TileMetadata {
+	types := []string{"string", "boolean", "integer"}
+	forms := []string{"networking", "security", "domains"}
+	tile := &metadata.TileMetadata{}
+	formInputs := make(map[string][]metadata.PropertyInput)
+
+	for i := 0; i < 60; i++ {
+		if r.Intn(3) == 0 {
+			continue
+		}
+		name := fmt.Sprintf("prop_%02d", i)
+		tile.PropertyBlueprints = append(tile.PropertyBlueprints, metadata.PropertyBlueprint{
+			Name:         name,
+			Type:         types[r.Intn(len(types))],
+			Configurable: true,
+			Optional:     r.Intn(2) == 0,
+		})
+		if form := r.Intn(len(forms) + 1); form < len(forms) {
+			formInputs[forms[form]] = append(formInputs[forms[form]], metadata.PropertyInput{Reference: ".properties." + name, Label: "Label " + name})
+		}
+	}
+	for _, form := range forms {
+		tile.FormTypes = append(tile.FormTypes, metadata.FormType{Name: form, Label: strings.ToUpper(form), PropertyInputs: formInputs[form]})
+	}
+	for i := 0; i < 10; i++ {
+		if r.Intn(2) == 0 {
+			tile.Releases = append(tile.Releases, metadata.Release{Name: fmt.Sprintf("release-%d", i), Version: fmt.Sprintf("1.%d.0", r.Intn(3))})
+		}
+		if r.Intn(2) == 0 {
+			tile.Variables = append(tile.Variables, metadata.Variable{
+				Name:    fmt.Sprintf("cert_%d", i),
+				Type:    "certificate",
+				Options: map[string]interface{}{"common_name": fmt.Sprintf("cn-%d", r.Intn(2)), "is_ca": r.Intn(2) == 0},
+			})
+		}
+	}
+
+	return tile
+}
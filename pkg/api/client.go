@@ -14,11 +14,11 @@ import (
 
 // Client represents an Ops Manager API client
 type Client struct {
-	baseURL     string
-	username    string
-	password    string
-	httpClient  *http.Client
-	accessToken string
+	baseURL      string
+	username     string
+	password     string
+	httpClient   *http.Client
+	accessToken  string
 	useBasicAuth bool
 }
 
@@ -93,20 +93,20 @@ func (c *Client) authenticate() error {
 	return nil
 }
 
-// GetProperties retrieves properties for a staged product
-func (c *Client) GetProperties(productGUID string) (*PropertiesResponse, error) {
+// get performs an authenticated GET request and decodes the JSON response into out
+func (c *Client) get(path string, out interface{}) error {
 	// Authenticate if we don't have a token and not using basic auth
 	if c.accessToken == "" && !c.useBasicAuth {
 		if err := c.authenticate(); err != nil {
-			return nil, fmt.Errorf("failed to authenticate: %w", err)
+			return fmt.Errorf("failed to authenticate: %w", err)
 		}
 	}
 
-	url := fmt.Sprintf("%s/api/v0/staged/products/%s/properties", c.baseURL, productGUID)
+	url := fmt.Sprintf("%s%s", c.baseURL, path)
 
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return fmt.Errorf("failed to create request: %w", err)
 	}
 
 	// Use basic auth or bearer token based on what's available
@@ -119,19 +119,27 @@ func (c *Client) GetProperties(productGUID string) (*PropertiesResponse, error)
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute request: %w", err)
+		return fmt.Errorf("failed to execute request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+		return fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
 	}
 
+	return nil
+}
+
+// GetProperties retrieves properties for a staged product
+func (c *Client) GetProperties(productGUID string) (*PropertiesResponse, error) {
 	var properties PropertiesResponse
-	err = json.NewDecoder(resp.Body).Decode(&properties)
-	if err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	if err := c.get(fmt.Sprintf("/api/v0/staged/products/%s/properties", productGUID), &properties); err != nil {
+		return nil, err
 	}
 
 	return &properties, nil
@@ -139,46 +147,52 @@ func (c *Client) GetProperties(productGUID string) (*PropertiesResponse, error)
 
 // GetStagedProducts retrieves all staged products
 func (c *Client) GetStagedProducts() ([]StagedProduct, error) {
-	// Authenticate if we don't have a token and not using basic auth
-	if c.accessToken == "" && !c.useBasicAuth {
-		if err := c.authenticate(); err != nil {
-			return nil, fmt.Errorf("failed to authenticate: %w", err)
-		}
+	var products []StagedProduct
+	if err := c.get("/api/v0/staged/products", &products); err != nil {
+		return nil, err
 	}
 
-	url := fmt.Sprintf("%s/api/v0/staged/products", c.baseURL)
+	return products, nil
+}
 
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+// GetStemcellAssociations retrieves the stemcell library and per-product stemcell assignments
+func (c *Client) GetStemcellAssociations() (*StemcellAssociationsResponse, error) {
+	var associations StemcellAssociationsResponse
+	if err := c.get("/api/v0/stemcell_associations", &associations); err != nil {
+		return nil, err
 	}
 
-	// Use basic auth or bearer token based on what's available
-	if c.useBasicAuth {
-		req.SetBasicAuth(c.username, c.password)
-	} else {
-		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.accessToken))
-	}
-	req.Header.Set("Accept", "application/json")
+	return &associations, nil
+}
 
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to execute request: %w", err)
+// GetDeployedProducts retrieves all products deployed by Ops Manager
+func (c *Client) GetDeployedProducts() ([]DeployedProduct, error) {
+	var products []DeployedProduct
+	if err := c.get("/api/v0/deployed/products", &products); err != nil {
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+	return products, nil
+}
+
+// GetOpsManagerVersion retrieves the Ops Manager version
+func (c *Client) GetOpsManagerVersion() (string, error) {
+	var info InfoResponse
+	if err := c.get("/api/v0/info", &info); err != nil {
+		return "", err
 	}
 
-	var products []StagedProduct
-	err = json.NewDecoder(resp.Body).Decode(&products)
-	if err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	return info.Info.Version, nil
+}
+
+// GetErrands retrieves the errand run configuration for a staged product
+func (c *Client) GetErrands(productGUID string) ([]Errand, error) {
+	var errands ErrandsResponse
+	if err := c.get(fmt.Sprintf("/api/v0/staged/products/%s/errands", productGUID), &errands); err != nil {
+		return nil, err
 	}
 
-	return products, nil
+	return errands.Errands, nil
 }
 
 // FindProductGUID finds a product GUID by product slug/type
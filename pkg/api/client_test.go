@@ -279,3 +279,146 @@ func TestFindProductGUID(t *testing.T) {
 		t.Errorf("Expected error '%s', got '%s'", expectedError, err.Error())
 	}
 }
+
+func TestGetStemcellAssociations(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/uaa/oauth/token" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if r.URL.Path != "/api/v0/stemcell_associations" {
+			t.Errorf("Unexpected path: %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"products": [
+				{"guid": "cf-abc123xyz", "identifier": "cf", "available_stemcell_versions": ["1.500"]}
+			],
+			"stemcell_library": [
+				{"os": "ubuntu-jammy", "version": "1.500", "infrastructure": "vsphere", "filename": "bosh-stemcell-1.500-vsphere-esxi-ubuntu-jammy-go_agent.tgz"}
+			]
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "admin", "password", true)
+	associations, err := client.GetStemcellAssociations()
+	if err != nil {
+		t.Fatalf("GetStemcellAssociations failed: %v", err)
+	}
+
+	if len(associations.StemcellLibrary) != 1 {
+		t.Fatalf("Expected 1 stemcell in library, got %d", len(associations.StemcellLibrary))
+	}
+	if associations.StemcellLibrary[0].OS != "ubuntu-jammy" {
+		t.Errorf("Expected os 'ubuntu-jammy', got '%s'", associations.StemcellLibrary[0].OS)
+	}
+	if associations.StemcellLibrary[0].Version != "1.500" {
+		t.Errorf("Expected version '1.500', got '%s'", associations.StemcellLibrary[0].Version)
+	}
+	if len(associations.Products) != 1 || associations.Products[0].Identifier != "cf" {
+		t.Errorf("Expected cf product association, got %+v", associations.Products)
+	}
+}
+
+func TestGetDeployedProducts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/uaa/oauth/token" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if r.URL.Path != "/api/v0/deployed/products" {
+			t.Errorf("Unexpected path: %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[
+			{"installation_name": "p-bosh", "guid": "p-bosh-123", "type": "p-bosh", "product_version": "3.0.25-build.1"},
+			{"installation_name": "cf-abc123xyz", "guid": "cf-abc123xyz", "type": "cf", "product_version": "6.0.22"}
+		]`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "admin", "password", true)
+	products, err := client.GetDeployedProducts()
+	if err != nil {
+		t.Fatalf("GetDeployedProducts failed: %v", err)
+	}
+
+	if len(products) != 2 {
+		t.Fatalf("Expected 2 products, got %d", len(products))
+	}
+	if products[1].Type != "cf" || products[1].ProductVersion != "6.0.22" {
+		t.Errorf("Expected cf 6.0.22, got %s %s", products[1].Type, products[1].ProductVersion)
+	}
+}
+
+func TestGetOpsManagerVersion(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/uaa/oauth/token" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if r.URL.Path != "/api/v0/info" {
+			t.Errorf("Unexpected path: %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"info": {"version": "3.0.25-build.1"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "admin", "password", true)
+	version, err := client.GetOpsManagerVersion()
+	if err != nil {
+		t.Fatalf("GetOpsManagerVersion failed: %v", err)
+	}
+
+	if version != "3.0.25-build.1" {
+		t.Errorf("Expected version '3.0.25-build.1', got '%s'", version)
+	}
+}
+
+func TestGetErrands(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/uaa/oauth/token" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if r.URL.Path != "/api/v0/staged/products/cf-abc123xyz/errands" {
+			t.Errorf("Unexpected path: %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"errands": [
+			{"name": "smoke_tests", "label": "Smoke Test Errand", "post_deploy": "default"},
+			{"name": "push-usage-service", "post_deploy": false},
+			{"name": "delete-apps-manager", "pre_delete": true}
+		]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "admin", "password", true)
+	errands, err := client.GetErrands("cf-abc123xyz")
+	if err != nil {
+		t.Fatalf("GetErrands failed: %v", err)
+	}
+
+	if len(errands) != 3 {
+		t.Fatalf("Expected 3 errands, got %d", len(errands))
+	}
+	if errands[0].Name != "smoke_tests" || errands[0].PostDeploy != "default" {
+		t.Errorf("Expected smoke_tests with default post_deploy, got %s %v", errands[0].Name, errands[0].PostDeploy)
+	}
+	if errands[1].PostDeploy != false {
+		t.Errorf("Expected push-usage-service post_deploy false, got %v", errands[1].PostDeploy)
+	}
+	if errands[2].PreDelete != true || errands[2].PostDeploy != nil {
+		t.Errorf("Expected delete-apps-manager pre_delete only, got %v/%v", errands[2].PostDeploy, errands[2].PreDelete)
+	}
+}
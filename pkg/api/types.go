@@ -22,3 +22,54 @@ type StagedProduct struct {
 	GUID string `json:"guid"`
 	Type string `json:"type"`
 }
+
+// DeployedProduct represents a product deployed by Ops Manager
+type DeployedProduct struct {
+	InstallationName string `json:"installation_name"`
+	GUID             string `json:"guid"`
+	Type             string `json:"type"`
+	ProductVersion   string `json:"product_version"`
+}
+
+// InfoResponse represents the API response for Ops Manager information
+type InfoResponse struct {
+	Info struct {
+		Version string `json:"version"`
+	} `json:"info"`
+}
+
+// StemcellLibraryEntry represents a stemcell uploaded to Ops Manager
+type StemcellLibraryEntry struct {
+	OS             string `json:"os"`
+	Version        string `json:"version"`
+	Infrastructure string `json:"infrastructure,omitempty"`
+	Filename       string `json:"filename,omitempty"`
+}
+
+// StemcellAssociation represents the stemcells assigned to a product
+type StemcellAssociation struct {
+	GUID                      string   `json:"guid"`
+	Identifier                string   `json:"identifier"`
+	StagedProductVersion      string   `json:"staged_product_version,omitempty"`
+	AvailableStemcellVersions []string `json:"available_stemcell_versions,omitempty"`
+}
+
+// StemcellAssociationsResponse represents the API response for stemcell associations
+type StemcellAssociationsResponse struct {
+	Products        []StemcellAssociation  `json:"products"`
+	StemcellLibrary []StemcellLibraryEntry `json:"stemcell_library"`
+}
+
+// Errand represents the errand run configuration of a staged product; post_deploy and
+// pre_delete hold true, false, "when-changed", or "default"
+type Errand struct {
+	Name       string      `json:"name"`
+	Label      string      `json:"label,omitempty"`
+	PostDeploy interface{} `json:"post_deploy,omitempty"`
+	PreDelete  interface{} `json:"pre_delete,omitempty"`
+}
+
+// ErrandsResponse represents the API response for a staged product's errands
+type ErrandsResponse struct {
+	Errands []Errand `json:"errands"`
+}
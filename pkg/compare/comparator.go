@@ -2,7 +2,11 @@
 // ABOUTME: Provides single entry point for comparing two tile metadata versions.
 package compare
 
-import "github.com/malston/tile-diff/pkg/metadata"
+import (
+	"sort"
+
+	"github.com/malston/tile-diff/pkg/metadata"
+)
 
 // CompareMetadata performs a complete comparison between old and new tile metadata
 func CompareMetadata(oldMetadata, newMetadata *metadata.TileMetadata, configurableOnly bool) *ComparisonResults {
@@ -15,12 +19,45 @@ func CompareMetadata(oldMetadata, newMetadata *metadata.TileMetadata, configurab
 	removed := FindRemovedProperties(oldProps, newProps, configurableOnly)
 	changed := FindChangedProperties(oldProps, newProps, configurableOnly)
 
+	// Label changes with the Ops Manager form and field that expose them
+	oldForms := BuildFormIndex(oldMetadata.FormTypes)
+	newForms := BuildFormIndex(newMetadata.FormTypes)
+	AttachForms(added, oldForms, newForms)
+	AttachForms(removed, oldForms, newForms)
+	AttachForms(changed, oldForms, newForms)
+
+	// Order by form, then property name; properties not on any form come last
+	SortResults(added)
+	SortResults(removed)
+	SortResults(changed)
+
 	return &ComparisonResults{
 		Added:            added,
 		Removed:          removed,
 		Changed:          changed,
+		Stemcells:        CompareStemcells(oldMetadata, newMetadata),
+		Releases:         CompareReleases(oldMetadata, newMetadata),
+		Dependencies:     CompareDependencies(oldMetadata, newMetadata),
+		Errands:          CompareErrands(oldMetadata, newMetadata),
+		Forms:            CompareForms(oldMetadata, newMetadata),
+		Variables:        CompareVariables(oldMetadata, newMetadata),
+		RuntimeConfigs:   CompareRuntimeConfigs(oldMetadata, newMetadata),
 		TotalOldProps:    len(oldMetadata.PropertyBlueprints),
 		TotalNewProps:    len(newMetadata.PropertyBlueprints),
 		ConfigurableOnly: configurableOnly,
 	}
 }
+
+// SortResults orders property changes by Ops Manager form, then property name, with unplaced properties last
+func SortResults(results []ComparisonResult) {
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if a.FormName != b.FormName {
+			if a.FormName == "" || b.FormName == "" {
+				return b.FormName == ""
+			}
+			return a.FormName < b.FormName
+		}
+		return a.PropertyName < b.PropertyName
+	})
+}
@@ -3,8 +3,11 @@
 package compare
 
 import (
+	"reflect"
 	"testing"
+	"testing/quick"
 
+	"github.com/malston/tile-diff/internal/tiletest"
 	"github.com/malston/tile-diff/pkg/metadata"
 )
 
@@ -52,3 +55,58 @@ func TestCompareMetadata(t *testing.T) {
 		t.Errorf("Expected 1 changed property, got %d", len(results.Changed))
 	}
 }
+
+func TestCompareMetadata_StableOrder(t *testing.T) {
+	stable := func(seed int64) bool {
+		oldTile, newTile := tiletest.RandomTilePair(seed)
+		first := CompareMetadata(oldTile, newTile, true)
+		for run := 0; run < 10; run++ {
+			if !reflect.DeepEqual(first, CompareMetadata(oldTile, newTile, true)) {
+				return false
+			}
+		}
+		return isSortedByFormThenName(first.Added) && isSortedByFormThenName(first.Removed) && isSortedByFormThenName(first.Changed)
+	}
+
+	if err := quick.Check(stable, &quick.Config{MaxCount: 25}); err != nil {
+		t.Error(err)
+	}
+}
+
+func isSortedByFormThenName(results []ComparisonResult) bool {
+	for i := 1; i < len(results); i++ {
+		prev, cur := results[i-1], results[i]
+		switch {
+		case prev.FormName == cur.FormName:
+			if prev.PropertyName > cur.PropertyName {
+				return false
+			}
+		case prev.FormName == "":
+			return false
+		case cur.FormName != "" && prev.FormName > cur.FormName:
+			return false
+		}
+	}
+	return true
+}
+
+func TestSortResults(t *testing.T) {
+	results := []ComparisonResult{
+		{PropertyName: "b_unplaced"},
+		{PropertyName: "z_prop", FormName: "networking"},
+		{PropertyName: "a_unplaced"},
+		{PropertyName: "m_prop", FormName: "domains"},
+		{PropertyName: "a_prop", FormName: "networking"},
+	}
+
+	SortResults(results)
+
+	var got []string
+	for _, result := range results {
+		got = append(got, result.PropertyName)
+	}
+	want := []string{"m_prop", "a_prop", "z_prop", "a_unplaced", "b_unplaced"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
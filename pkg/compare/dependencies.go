@@ -0,0 +1,67 @@
+// ABOUTME: Product dependency comparison between tile versions.
+// ABOUTME: Detects added, removed, and tightened requires_product_versions entries.
+package compare
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/malston/tile-diff/pkg/metadata"
+)
+
+// CompareDependencies identifies required product versions that differ between old and new metadata
+func CompareDependencies(oldMetadata, newMetadata *metadata.TileMetadata) []DependencyChange {
+	oldDeps := buildDependencyMap(oldMetadata.RequiresProductVersions)
+	newDeps := buildDependencyMap(newMetadata.RequiresProductVersions)
+
+	var changes []DependencyChange
+
+	for name, newConstraint := range newDeps {
+		oldConstraint, exists := oldDeps[name]
+		if !exists {
+			changes = append(changes, DependencyChange{
+				Name:          name,
+				ChangeType:    DependencyAdded,
+				NewConstraint: newConstraint,
+				Description:   fmt.Sprintf("New dependency: %s %s", name, newConstraint),
+			})
+			continue
+		}
+
+		if oldConstraint != newConstraint {
+			changes = append(changes, DependencyChange{
+				Name:          name,
+				ChangeType:    DependencyChanged,
+				OldConstraint: oldConstraint,
+				NewConstraint: newConstraint,
+				Description:   fmt.Sprintf("Required version changed from %s to %s", oldConstraint, newConstraint),
+			})
+		}
+	}
+
+	for name, oldConstraint := range oldDeps {
+		if _, exists := newDeps[name]; !exists {
+			changes = append(changes, DependencyChange{
+				Name:          name,
+				ChangeType:    DependencyRemoved,
+				OldConstraint: oldConstraint,
+				Description:   fmt.Sprintf("Dependency removed: %s %s", name, oldConstraint),
+			})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Name < changes[j].Name
+	})
+
+	return changes
+}
+
+// buildDependencyMap keys version constraints by product name
+func buildDependencyMap(requirements []metadata.ProductVersionRequirement) map[string]string {
+	depMap := make(map[string]string, len(requirements))
+	for _, req := range requirements {
+		depMap[req.Name] = req.Version
+	}
+	return depMap
+}
@@ -0,0 +1,48 @@
+// ABOUTME: Unit tests for product dependency comparison.
+// ABOUTME: Validates detection of added, removed, and changed version constraints.
+package compare
+
+import (
+	"testing"
+
+	"github.com/malston/tile-diff/pkg/metadata"
+)
+
+func TestCompareDependencies(t *testing.T) {
+	oldMetadata := &metadata.TileMetadata{
+		RequiresProductVersions: []metadata.ProductVersionRequirement{
+			{Name: "p-bosh", Version: "~> 2.10"},
+			{Name: "p-isolation-segment", Version: ">= 2.0"},
+		},
+	}
+	newMetadata := &metadata.TileMetadata{
+		RequiresProductVersions: []metadata.ProductVersionRequirement{
+			{Name: "p-bosh", Version: "~> 3.0"},
+			{Name: "cf", Version: ">= 10.0"},
+		},
+	}
+
+	changes := CompareDependencies(oldMetadata, newMetadata)
+	if len(changes) != 3 {
+		t.Fatalf("Expected 3 dependency changes, got %d", len(changes))
+	}
+
+	expected := []struct {
+		name       string
+		changeType ChangeType
+	}{
+		{"cf", DependencyAdded},
+		{"p-bosh", DependencyChanged},
+		{"p-isolation-segment", DependencyRemoved},
+	}
+	for i, want := range expected {
+		if changes[i].Name != want.name || changes[i].ChangeType != want.changeType {
+			t.Errorf("Change %d: expected %s (%s), got %s (%s)",
+				i, want.name, want.changeType, changes[i].Name, changes[i].ChangeType)
+		}
+	}
+
+	if changes[1].OldConstraint != "~> 2.10" || changes[1].NewConstraint != "~> 3.0" {
+		t.Errorf("Expected p-bosh ~> 2.10 -> ~> 3.0, got %s -> %s", changes[1].OldConstraint, changes[1].NewConstraint)
+	}
+}
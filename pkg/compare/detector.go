@@ -4,6 +4,7 @@ package compare
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/malston/tile-diff/pkg/metadata"
 )
@@ -12,7 +13,9 @@ import (
 func FindNewProperties(oldProps, newProps map[string]metadata.PropertyBlueprint, configurableOnly bool) []ComparisonResult {
 	var results []ComparisonResult
 
-	for name, newProp := range newProps {
+	for _, name := range sortedPropertyNames(newProps) {
+		newProp := newProps[name]
+
 		// Skip if property exists in old version
 		if _, exists := oldProps[name]; exists {
 			continue
@@ -40,7 +43,9 @@ func FindNewProperties(oldProps, newProps map[string]metadata.PropertyBlueprint,
 func FindRemovedProperties(oldProps, newProps map[string]metadata.PropertyBlueprint, configurableOnly bool) []ComparisonResult {
 	var results []ComparisonResult
 
-	for name, oldProp := range oldProps {
+	for _, name := range sortedPropertyNames(oldProps) {
+		oldProp := oldProps[name]
+
 		// Skip if property still exists in new version
 		if _, exists := newProps[name]; exists {
 			continue
@@ -68,7 +73,8 @@ func FindRemovedProperties(oldProps, newProps map[string]metadata.PropertyBluepr
 func FindChangedProperties(oldProps, newProps map[string]metadata.PropertyBlueprint, configurableOnly bool) []ComparisonResult {
 	var results []ComparisonResult
 
-	for name, oldProp := range oldProps {
+	for _, name := range sortedPropertyNames(oldProps) {
+		oldProp := oldProps[name]
 		newProp, exists := newProps[name]
 		if !exists {
 			// Property was removed, not changed
@@ -108,3 +114,13 @@ func FindChangedProperties(oldProps, newProps map[string]metadata.PropertyBluepr
 
 	return results
 }
+
+// sortedPropertyNames returns the keys of props in sorted order so results are stable across runs
+func sortedPropertyNames(props map[string]metadata.PropertyBlueprint) []string {
+	names := make([]string, 0, len(props))
+	for name := range props {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
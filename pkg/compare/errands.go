@@ -0,0 +1,103 @@
+// ABOUTME: Errand comparison between tile versions.
+// ABOUTME: Detects added and removed errands and changes to their default run state.
+package compare
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/malston/tile-diff/pkg/metadata"
+)
+
+// Errand lifecycles reported in ErrandChange
+const (
+	PostDeployErrand = "post-deploy"
+	PreDeleteErrand  = "pre-delete"
+)
+
+// CompareErrands identifies post-deploy and pre-delete errands that were added, removed, or
+// whose default run state changed
+func CompareErrands(oldMetadata, newMetadata *metadata.TileMetadata) []ErrandChange {
+	var changes []ErrandChange
+	changes = append(changes, compareErrandList(PostDeployErrand, oldMetadata.PostDeployErrands, newMetadata.PostDeployErrands)...)
+	changes = append(changes, compareErrandList(PreDeleteErrand, oldMetadata.PreDeleteErrands, newMetadata.PreDeleteErrands)...)
+
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].Lifecycle != changes[j].Lifecycle {
+			return changes[i].Lifecycle == PostDeployErrand
+		}
+		return changes[i].Name < changes[j].Name
+	})
+
+	return changes
+}
+
+// compareErrandList compares the errands of a single lifecycle
+func compareErrandList(lifecycle string, oldErrands, newErrands []metadata.Errand) []ErrandChange {
+	oldMap := buildErrandMap(oldErrands)
+	newMap := buildErrandMap(newErrands)
+
+	var changes []ErrandChange
+
+	for name, newErrand := range newMap {
+		oldErrand, exists := oldMap[name]
+		if !exists {
+			changes = append(changes, ErrandChange{
+				Name:        name,
+				Lifecycle:   lifecycle,
+				ChangeType:  ErrandAdded,
+				NewDefault:  newErrand.DefaultState(),
+				Description: fmt.Sprintf("New %s errand (runs by default: %s)", lifecycle, newErrand.DefaultState()),
+			})
+			continue
+		}
+
+		if oldErrand.DefaultState() != newErrand.DefaultState() {
+			changes = append(changes, ErrandChange{
+				Name:        name,
+				Lifecycle:   lifecycle,
+				ChangeType:  ErrandChanged,
+				OldDefault:  oldErrand.DefaultState(),
+				NewDefault:  newErrand.DefaultState(),
+				Description: fmt.Sprintf("Default run state changed from %s to %s", oldErrand.DefaultState(), newErrand.DefaultState()),
+			})
+		}
+	}
+
+	for name, oldErrand := range oldMap {
+		if _, exists := newMap[name]; !exists {
+			changes = append(changes, ErrandChange{
+				Name:        name,
+				Lifecycle:   lifecycle,
+				ChangeType:  ErrandRemoved,
+				OldDefault:  oldErrand.DefaultState(),
+				Description: fmt.Sprintf("%s errand removed", lifecycle),
+			})
+		}
+	}
+
+	return changes
+}
+
+// StartsRunningByDefault reports whether the change makes an errand run by default when it previously did not
+func (c ErrandChange) StartsRunningByDefault() bool {
+	if c.NewDefault == metadata.ErrandRunOff {
+		return false
+	}
+	switch c.ChangeType {
+	case ErrandAdded:
+		return true
+	case ErrandChanged:
+		return c.OldDefault == metadata.ErrandRunOff
+	}
+	return false
+}
+
+// buildErrandMap keys errands by name
+func buildErrandMap(errands []metadata.Errand) map[string]metadata.Errand {
+	errandMap := make(map[string]metadata.Errand, len(errands))
+	for _, errand := range errands {
+		errandMap[errand.Name] = errand
+	}
+	return errandMap
+}
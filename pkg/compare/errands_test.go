@@ -0,0 +1,63 @@
+// ABOUTME: Unit tests for errand comparison.
+// ABOUTME: Validates detection of added, removed, and default run state changes.
+package compare
+
+import (
+	"testing"
+
+	"github.com/malston/tile-diff/pkg/metadata"
+)
+
+func TestCompareErrands(t *testing.T) {
+	oldMetadata := &metadata.TileMetadata{
+		PostDeployErrands: []metadata.Errand{
+			{Name: "smoke_tests"},
+			{Name: "push-usage-service", RunDefault: false},
+			{Name: "deploy-notifications"},
+		},
+		PreDeleteErrands: []metadata.Errand{
+			{Name: "delete-apps-manager"},
+		},
+	}
+	newMetadata := &metadata.TileMetadata{
+		PostDeployErrands: []metadata.Errand{
+			{Name: "smoke_tests"},
+			{Name: "push-usage-service", RunDefault: true},
+			{Name: "rotate_cc_database_key", RunDefault: "when-changed"},
+		},
+		PreDeleteErrands: []metadata.Errand{
+			{Name: "delete-apps-manager", RunDefault: false},
+		},
+	}
+
+	changes := CompareErrands(oldMetadata, newMetadata)
+	if len(changes) != 4 {
+		t.Fatalf("Expected 4 errand changes, got %d", len(changes))
+	}
+
+	expected := []struct {
+		name       string
+		lifecycle  string
+		changeType ChangeType
+		starts     bool
+	}{
+		{"deploy-notifications", PostDeployErrand, ErrandRemoved, false},
+		{"push-usage-service", PostDeployErrand, ErrandChanged, true},
+		{"rotate_cc_database_key", PostDeployErrand, ErrandAdded, true},
+		{"delete-apps-manager", PreDeleteErrand, ErrandChanged, false},
+	}
+	for i, want := range expected {
+		got := changes[i]
+		if got.Name != want.name || got.Lifecycle != want.lifecycle || got.ChangeType != want.changeType {
+			t.Errorf("Change %d: expected %s %s (%s), got %s %s (%s)",
+				i, want.lifecycle, want.name, want.changeType, got.Lifecycle, got.Name, got.ChangeType)
+		}
+		if got.StartsRunningByDefault() != want.starts {
+			t.Errorf("Change %d: expected StartsRunningByDefault %v for %s", i, want.starts, got.Name)
+		}
+	}
+
+	if changes[1].OldDefault != metadata.ErrandRunOff || changes[1].NewDefault != metadata.ErrandRunOn {
+		t.Errorf("Expected push-usage-service off -> on, got %s -> %s", changes[1].OldDefault, changes[1].NewDefault)
+	}
+}
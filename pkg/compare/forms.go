@@ -0,0 +1,117 @@
+// ABOUTME: Ops Manager form comparison and property-to-form placement.
+// ABOUTME: Detects added and removed form tabs and labels property changes with their tab and field.
+package compare
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/malston/tile-diff/pkg/metadata"
+)
+
+// FormLocation identifies where a property appears in the Ops Manager UI
+type FormLocation struct {
+	FormName            string
+	FormLabel           string
+	PropertyLabel       string
+	PropertyDescription string
+}
+
+// CompareForms identifies form tabs that were added or removed
+func CompareForms(oldMetadata, newMetadata *metadata.TileMetadata) []FormChange {
+	oldForms := buildFormMap(oldMetadata.FormTypes)
+	newForms := buildFormMap(newMetadata.FormTypes)
+
+	var changes []FormChange
+
+	for name, form := range newForms {
+		if _, exists := oldForms[name]; !exists {
+			changes = append(changes, FormChange{
+				Name:        name,
+				Label:       form.Label,
+				ChangeType:  FormAdded,
+				Description: fmt.Sprintf("New form tab: %s", form.Label),
+			})
+		}
+	}
+
+	for name, form := range oldForms {
+		if _, exists := newForms[name]; !exists {
+			changes = append(changes, FormChange{
+				Name:        name,
+				Label:       form.Label,
+				ChangeType:  FormRemoved,
+				Description: fmt.Sprintf("Form tab removed: %s", form.Label),
+			})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Name < changes[j].Name
+	})
+
+	return changes
+}
+
+// BuildFormIndex maps property names to the form and field label that expose them
+func BuildFormIndex(forms []metadata.FormType) map[string]FormLocation {
+	index := make(map[string]FormLocation)
+	for _, form := range forms {
+		indexPropertyInputs(index, form, form.PropertyInputs)
+	}
+	return index
+}
+
+// AttachForms labels each result with its form placement, preferring the new tile's forms
+// and falling back to the old tile's forms for removed properties
+func AttachForms(results []ComparisonResult, oldIndex, newIndex map[string]FormLocation) {
+	for i := range results {
+		location, ok := newIndex[results[i].PropertyName]
+		if !ok {
+			location, ok = oldIndex[results[i].PropertyName]
+		}
+		if !ok {
+			continue
+		}
+		results[i].FormName = location.FormName
+		results[i].FormLabel = location.FormLabel
+		results[i].PropertyLabel = location.PropertyLabel
+		results[i].PropertyDescription = location.PropertyDescription
+	}
+}
+
+// indexPropertyInputs records the location of each input, descending into selector options
+func indexPropertyInputs(index map[string]FormLocation, form metadata.FormType, inputs []metadata.PropertyInput) {
+	for _, input := range inputs {
+		name := normalizeReference(input.Reference)
+		if _, exists := index[name]; !exists {
+			index[name] = FormLocation{
+				FormName:            form.Name,
+				FormLabel:           form.Label,
+				PropertyLabel:       input.Label,
+				PropertyDescription: input.Description,
+			}
+		}
+
+		indexPropertyInputs(index, form, input.PropertyInputs)
+		for _, selector := range input.SelectorPropertyInputs {
+			indexPropertyInputs(index, form, selector.PropertyInputs)
+		}
+	}
+}
+
+// normalizeReference converts a form reference like .properties.foo to the property name foo
+func normalizeReference(reference string) string {
+	name := strings.TrimPrefix(reference, ".properties.")
+	return strings.TrimPrefix(name, ".")
+}
+
+// buildFormMap keys form types by name
+func buildFormMap(forms []metadata.FormType) map[string]metadata.FormType {
+	formMap := make(map[string]metadata.FormType, len(forms))
+	for _, form := range forms {
+		formMap[form.Name] = form
+	}
+	return formMap
+}
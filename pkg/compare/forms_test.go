@@ -0,0 +1,85 @@
+// ABOUTME: Unit tests for Ops Manager form comparison and placement.
+// ABOUTME: Validates form tab detection and labelling of property changes.
+package compare
+
+import (
+	"testing"
+
+	"github.com/malston/tile-diff/pkg/metadata"
+)
+
+func TestCompareForms(t *testing.T) {
+	oldMetadata := &metadata.TileMetadata{
+		FormTypes: []metadata.FormType{
+			{Name: "domains", Label: "Domains"},
+			{Name: "credhub", Label: "CredHub"},
+		},
+	}
+	newMetadata := &metadata.TileMetadata{
+		FormTypes: []metadata.FormType{
+			{Name: "domains", Label: "Domains"},
+			{Name: "app_security", Label: "App Security"},
+		},
+	}
+
+	changes := CompareForms(oldMetadata, newMetadata)
+	if len(changes) != 2 {
+		t.Fatalf("Expected 2 form changes, got %d", len(changes))
+	}
+	if changes[0].Name != "app_security" || changes[0].ChangeType != FormAdded {
+		t.Errorf("Expected app_security added, got %s (%s)", changes[0].Name, changes[0].ChangeType)
+	}
+	if changes[1].Name != "credhub" || changes[1].ChangeType != FormRemoved {
+		t.Errorf("Expected credhub removed, got %s (%s)", changes[1].Name, changes[1].ChangeType)
+	}
+}
+
+func TestAttachForms(t *testing.T) {
+	oldForms := []metadata.FormType{
+		{Name: "credhub", Label: "CredHub", PropertyInputs: []metadata.PropertyInput{
+			{Reference: ".properties.credhub_hsm_provider", Label: "HSM provider", Description: "Hardware security module used for encryption"},
+		}},
+	}
+	newForms := []metadata.FormType{
+		{Name: "networking", Label: "Networking", PropertyInputs: []metadata.PropertyInput{
+			{
+				Reference: ".properties.routing_tls_termination",
+				Label:     "TLS termination point",
+				SelectorPropertyInputs: []metadata.SelectorPropertyInput{
+					{Reference: ".properties.routing_tls_termination.router", Label: "Gorouter", PropertyInputs: []metadata.PropertyInput{
+						{Reference: ".properties.routing_tls_termination.router.min_tls_version", Label: "Minimum TLS version"},
+					}},
+				},
+			},
+		}},
+	}
+
+	results := []ComparisonResult{
+		{PropertyName: "routing_tls_termination", ChangeType: PropertyAdded},
+		{PropertyName: "routing_tls_termination.router.min_tls_version", ChangeType: PropertyAdded},
+		{PropertyName: "credhub_hsm_provider", ChangeType: PropertyRemoved},
+		{PropertyName: "hidden_property", ChangeType: PropertyAdded},
+	}
+
+	AttachForms(results, BuildFormIndex(oldForms), BuildFormIndex(newForms))
+
+	tests := []struct {
+		formLabel     string
+		propertyLabel string
+	}{
+		{"Networking", "TLS termination point"},
+		{"Networking", "Minimum TLS version"},
+		{"CredHub", "HSM provider"},
+		{"", ""},
+	}
+	for i, want := range tests {
+		if results[i].FormLabel != want.formLabel || results[i].PropertyLabel != want.propertyLabel {
+			t.Errorf("%s: expected %q/%q, got %q/%q", results[i].PropertyName,
+				want.formLabel, want.propertyLabel, results[i].FormLabel, results[i].PropertyLabel)
+		}
+	}
+
+	if results[2].PropertyDescription != "Hardware security module used for encryption" {
+		t.Errorf("Expected property description from the form, got %q", results[2].PropertyDescription)
+	}
+}
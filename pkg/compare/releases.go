@@ -0,0 +1,84 @@
+// ABOUTME: BOSH release comparison between tile versions.
+// ABOUTME: Detects added and removed releases and version bumps of bundled components.
+package compare
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/malston/tile-diff/pkg/metadata"
+)
+
+// CompareReleases identifies BOSH releases that were added, removed, or bumped
+func CompareReleases(oldMetadata, newMetadata *metadata.TileMetadata) []ReleaseChange {
+	oldReleases := buildReleaseMap(oldMetadata.Releases)
+	newReleases := buildReleaseMap(newMetadata.Releases)
+
+	var changes []ReleaseChange
+
+	for name, newRelease := range newReleases {
+		oldRelease, exists := oldReleases[name]
+		if !exists {
+			changes = append(changes, ReleaseChange{
+				Name:        name,
+				ChangeType:  ReleaseAdded,
+				NewVersion:  newRelease.Version,
+				File:        newRelease.File,
+				Description: fmt.Sprintf("New release: %s %s", name, newRelease.Version),
+			})
+			continue
+		}
+
+		if oldRelease.Version != newRelease.Version {
+			changes = append(changes, ReleaseChange{
+				Name:        name,
+				ChangeType:  ReleaseUpdated,
+				OldVersion:  oldRelease.Version,
+				NewVersion:  newRelease.Version,
+				File:        newRelease.File,
+				Description: fmt.Sprintf("Version changed from %s to %s", oldRelease.Version, newRelease.Version),
+			})
+		}
+	}
+
+	for name, oldRelease := range oldReleases {
+		if _, exists := newReleases[name]; !exists {
+			changes = append(changes, ReleaseChange{
+				Name:        name,
+				ChangeType:  ReleaseRemoved,
+				OldVersion:  oldRelease.Version,
+				Description: fmt.Sprintf("Release removed: %s %s", name, oldRelease.Version),
+			})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Name < changes[j].Name
+	})
+
+	return changes
+}
+
+// CheckReleaseFiles flags added or updated releases whose file is absent from the tile's releases/ directory
+func CheckReleaseFiles(changes []ReleaseChange, files []string) {
+	present := make(map[string]bool, len(files))
+	for _, file := range files {
+		present[file] = true
+	}
+
+	for i := range changes {
+		if changes[i].ChangeType == ReleaseRemoved || changes[i].File == "" {
+			continue
+		}
+		changes[i].FileMissing = !present[changes[i].File]
+	}
+}
+
+// buildReleaseMap keys releases by name
+func buildReleaseMap(releases []metadata.Release) map[string]metadata.Release {
+	releaseMap := make(map[string]metadata.Release, len(releases))
+	for _, release := range releases {
+		releaseMap[release.Name] = release
+	}
+	return releaseMap
+}
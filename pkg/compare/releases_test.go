@@ -0,0 +1,70 @@
+// ABOUTME: Unit tests for BOSH release comparison.
+// ABOUTME: Validates detection of added, removed, and bumped releases.
+package compare
+
+import (
+	"testing"
+
+	"github.com/malston/tile-diff/pkg/metadata"
+)
+
+func TestCompareReleases(t *testing.T) {
+	oldMetadata := &metadata.TileMetadata{
+		Releases: []metadata.Release{
+			{Name: "capi", File: "capi-1.2.3.tgz", Version: "1.2.3"},
+			{Name: "diego", File: "diego-2.100.0.tgz", Version: "2.100.0"},
+			{Name: "consul", File: "consul-1.0.0.tgz", Version: "1.0.0"},
+		},
+	}
+	newMetadata := &metadata.TileMetadata{
+		Releases: []metadata.Release{
+			{Name: "capi", File: "capi-1.5.0.tgz", Version: "1.5.0"},
+			{Name: "diego", File: "diego-2.100.0.tgz", Version: "2.100.0"},
+			{Name: "routing", File: "routing-0.300.0.tgz", Version: "0.300.0"},
+		},
+	}
+
+	changes := CompareReleases(oldMetadata, newMetadata)
+	if len(changes) != 3 {
+		t.Fatalf("Expected 3 release changes, got %d", len(changes))
+	}
+
+	expected := []struct {
+		name       string
+		changeType ChangeType
+	}{
+		{"capi", ReleaseUpdated},
+		{"consul", ReleaseRemoved},
+		{"routing", ReleaseAdded},
+	}
+	for i, want := range expected {
+		if changes[i].Name != want.name || changes[i].ChangeType != want.changeType {
+			t.Errorf("Change %d: expected %s (%s), got %s (%s)",
+				i, want.name, want.changeType, changes[i].Name, changes[i].ChangeType)
+		}
+	}
+
+	if changes[0].OldVersion != "1.2.3" || changes[0].NewVersion != "1.5.0" {
+		t.Errorf("Expected capi 1.2.3 -> 1.5.0, got %s -> %s", changes[0].OldVersion, changes[0].NewVersion)
+	}
+}
+
+func TestCheckReleaseFiles(t *testing.T) {
+	changes := []ReleaseChange{
+		{Name: "capi", ChangeType: ReleaseUpdated, File: "capi-1.5.0.tgz"},
+		{Name: "routing", ChangeType: ReleaseAdded, File: "routing-0.300.0.tgz"},
+		{Name: "consul", ChangeType: ReleaseRemoved},
+	}
+
+	CheckReleaseFiles(changes, []string{"capi-1.5.0.tgz"})
+
+	if changes[0].FileMissing {
+		t.Error("Expected capi release file to be found")
+	}
+	if !changes[1].FileMissing {
+		t.Error("Expected routing release file to be reported missing")
+	}
+	if changes[2].FileMissing {
+		t.Error("Removed releases should not be checked for files")
+	}
+}
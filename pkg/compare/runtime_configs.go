@@ -0,0 +1,189 @@
+// ABOUTME: Runtime config comparison between tile versions.
+// ABOUTME: Structurally diffs embedded manifests by addon, job, placement rule, and release.
+package compare
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/malston/tile-diff/pkg/metadata"
+)
+
+// CompareRuntimeConfigs identifies runtime configs that were added or removed and
+// structural changes to the manifests of configs present in both versions
+func CompareRuntimeConfigs(oldMetadata, newMetadata *metadata.TileMetadata) []RuntimeConfigChange {
+	oldConfigs := buildRuntimeConfigMap(oldMetadata.RuntimeConfigs)
+	newConfigs := buildRuntimeConfigMap(newMetadata.RuntimeConfigs)
+
+	var changes []RuntimeConfigChange
+
+	for name, newConfig := range newConfigs {
+		oldConfig, exists := oldConfigs[name]
+		if !exists {
+			changes = append(changes, RuntimeConfigChange{
+				ConfigName:  name,
+				ChangeType:  RuntimeConfigAdded,
+				Description: fmt.Sprintf("New runtime config: %s", name),
+			})
+			continue
+		}
+
+		if oldConfig.RuntimeConfig == newConfig.RuntimeConfig {
+			continue
+		}
+		changes = append(changes, diffRuntimeConfig(oldConfig, newConfig)...)
+	}
+
+	for name := range oldConfigs {
+		if _, exists := newConfigs[name]; !exists {
+			changes = append(changes, RuntimeConfigChange{
+				ConfigName:  name,
+				ChangeType:  RuntimeConfigRemoved,
+				Description: fmt.Sprintf("Runtime config removed: %s", name),
+			})
+		}
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].ConfigName < changes[j].ConfigName
+	})
+
+	return changes
+}
+
+// diffRuntimeConfig compares the manifests of a runtime config present in both versions
+func diffRuntimeConfig(oldConfig, newConfig metadata.RuntimeConfig) []RuntimeConfigChange {
+	name := newConfig.Name
+
+	oldManifest, oldErr := oldConfig.Manifest()
+	newManifest, newErr := newConfig.Manifest()
+	if oldErr != nil || newErr != nil {
+		return []RuntimeConfigChange{{
+			ConfigName:  name,
+			ChangeType:  RuntimeConfigChanged,
+			Description: "Runtime config changed (manifest could not be parsed for a detailed diff)",
+		}}
+	}
+
+	var changes []RuntimeConfigChange
+	add := func(subject, description string) {
+		changes = append(changes, RuntimeConfigChange{
+			ConfigName:  name,
+			Subject:     subject,
+			ChangeType:  RuntimeConfigChanged,
+			Description: description,
+		})
+	}
+
+	oldAddons := buildAddonMap(oldManifest.Addons)
+	newAddons := buildAddonMap(newManifest.Addons)
+	for _, addonName := range unionKeys(oldAddons, newAddons) {
+		oldAddon, inOld := oldAddons[addonName]
+		newAddon, inNew := newAddons[addonName]
+		subject := fmt.Sprintf("addon %s", addonName)
+
+		switch {
+		case !inOld:
+			add(subject, fmt.Sprintf("New addon with %d job(s)", len(newAddon.Jobs)))
+		case !inNew:
+			add(subject, "Addon removed")
+		default:
+			diffAddon(oldAddon, newAddon, subject, add)
+		}
+	}
+
+	oldReleases := buildReleaseMap(oldManifest.Releases)
+	newReleases := buildReleaseMap(newManifest.Releases)
+	for _, releaseName := range unionKeys(oldReleases, newReleases) {
+		oldRelease, inOld := oldReleases[releaseName]
+		newRelease, inNew := newReleases[releaseName]
+		subject := fmt.Sprintf("release %s", releaseName)
+
+		switch {
+		case !inOld:
+			add(subject, fmt.Sprintf("New release %s", newRelease.Version))
+		case !inNew:
+			add(subject, "Release removed")
+		case oldRelease.Version != newRelease.Version:
+			add(subject, fmt.Sprintf("Version changed from %s to %s", oldRelease.Version, newRelease.Version))
+		}
+	}
+
+	if len(changes) == 0 {
+		add("", "Runtime config manifest changed")
+	}
+
+	return changes
+}
+
+// diffAddon compares jobs and placement rules of an addon present in both versions
+func diffAddon(oldAddon, newAddon metadata.Addon, subject string, add func(subject, description string)) {
+	oldJobs := buildAddonJobMap(oldAddon.Jobs)
+	newJobs := buildAddonJobMap(newAddon.Jobs)
+	for _, jobName := range unionKeys(oldJobs, newJobs) {
+		oldJob, inOld := oldJobs[jobName]
+		newJob, inNew := newJobs[jobName]
+		jobSubject := fmt.Sprintf("%s job %s", subject, jobName)
+
+		switch {
+		case !inOld:
+			add(jobSubject, fmt.Sprintf("New job from release %s", newJob.Release))
+		case !inNew:
+			add(jobSubject, "Job removed")
+		case oldJob.Release != newJob.Release:
+			add(jobSubject, fmt.Sprintf("Release changed from %s to %s", oldJob.Release, newJob.Release))
+		case !reflect.DeepEqual(oldJob.Properties, newJob.Properties):
+			add(jobSubject, "Job properties changed")
+		}
+	}
+
+	if !reflect.DeepEqual(oldAddon.Include, newAddon.Include) {
+		add(subject, "Include rules changed; the set of deployments receiving this addon may differ")
+	}
+	if !reflect.DeepEqual(oldAddon.Exclude, newAddon.Exclude) {
+		add(subject, "Exclude rules changed; the set of deployments receiving this addon may differ")
+	}
+}
+
+// unionKeys returns the sorted keys present in either map
+func unionKeys[V any](a, b map[string]V) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for key := range a {
+		keys = append(keys, key)
+	}
+	for key := range b {
+		if _, exists := a[key]; !exists {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// buildRuntimeConfigMap keys runtime configs by name
+func buildRuntimeConfigMap(configs []metadata.RuntimeConfig) map[string]metadata.RuntimeConfig {
+	configMap := make(map[string]metadata.RuntimeConfig, len(configs))
+	for _, config := range configs {
+		configMap[config.Name] = config
+	}
+	return configMap
+}
+
+// buildAddonMap keys addons by name
+func buildAddonMap(addons []metadata.Addon) map[string]metadata.Addon {
+	addonMap := make(map[string]metadata.Addon, len(addons))
+	for _, addon := range addons {
+		addonMap[addon.Name] = addon
+	}
+	return addonMap
+}
+
+// buildAddonJobMap keys addon jobs by name
+func buildAddonJobMap(jobs []metadata.AddonJob) map[string]metadata.AddonJob {
+	jobMap := make(map[string]metadata.AddonJob, len(jobs))
+	for _, job := range jobs {
+		jobMap[job.Name] = job
+	}
+	return jobMap
+}
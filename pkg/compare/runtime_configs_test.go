@@ -0,0 +1,108 @@
+// ABOUTME: Unit tests for runtime config comparison.
+// ABOUTME: Validates structural diffs of addons, jobs, placement rules, and releases.
+package compare
+
+import (
+	"testing"
+
+	"github.com/malston/tile-diff/pkg/metadata"
+)
+
+const oldDNSRuntimeConfig = `
+releases:
+  - name: bosh-dns-aliases
+    version: 0.0.3
+addons:
+  - name: cf-dns-aliases
+    jobs:
+      - name: bosh-dns-aliases
+        release: bosh-dns-aliases
+        properties:
+          aliases:
+            - domain: sql-db.service.cf.internal
+    include:
+      stemcell:
+        - os: ubuntu-jammy
+  - name: legacy-syslog
+    jobs:
+      - name: syslog_forwarder
+        release: syslog
+`
+
+const newDNSRuntimeConfig = `
+releases:
+  - name: bosh-dns-aliases
+    version: 0.0.4
+addons:
+  - name: cf-dns-aliases
+    jobs:
+      - name: bosh-dns-aliases
+        release: bosh-dns-aliases
+        properties:
+          aliases:
+            - domain: sql-db.service.cf.internal
+            - domain: uaa.service.cf.internal
+      - name: dns-healthcheck
+        release: bosh-dns-aliases
+    include:
+      stemcell:
+        - os: ubuntu-jammy
+    exclude:
+      deployments: [p-bosh]
+`
+
+func TestCompareRuntimeConfigs(t *testing.T) {
+	oldMetadata := &metadata.TileMetadata{
+		RuntimeConfigs: []metadata.RuntimeConfig{
+			{Name: "cf-dns-aliases", RuntimeConfig: oldDNSRuntimeConfig},
+			{Name: "old-config", RuntimeConfig: "addons: []"},
+		},
+	}
+	newMetadata := &metadata.TileMetadata{
+		RuntimeConfigs: []metadata.RuntimeConfig{
+			{Name: "cf-dns-aliases", RuntimeConfig: newDNSRuntimeConfig},
+			{Name: "new-config", RuntimeConfig: "addons: []"},
+		},
+	}
+
+	changes := CompareRuntimeConfigs(oldMetadata, newMetadata)
+
+	expected := []struct {
+		config      string
+		subject     string
+		changeType  ChangeType
+		description string
+	}{
+		{"cf-dns-aliases", "addon cf-dns-aliases job bosh-dns-aliases", RuntimeConfigChanged, "Job properties changed"},
+		{"cf-dns-aliases", "addon cf-dns-aliases job dns-healthcheck", RuntimeConfigChanged, "New job from release bosh-dns-aliases"},
+		{"cf-dns-aliases", "addon cf-dns-aliases", RuntimeConfigChanged, "Exclude rules changed; the set of deployments receiving this addon may differ"},
+		{"cf-dns-aliases", "addon legacy-syslog", RuntimeConfigChanged, "Addon removed"},
+		{"cf-dns-aliases", "release bosh-dns-aliases", RuntimeConfigChanged, "Version changed from 0.0.3 to 0.0.4"},
+		{"new-config", "", RuntimeConfigAdded, "New runtime config: new-config"},
+		{"old-config", "", RuntimeConfigRemoved, "Runtime config removed: old-config"},
+	}
+
+	if len(changes) != len(expected) {
+		t.Fatalf("Expected %d runtime config changes, got %d: %+v", len(expected), len(changes), changes)
+	}
+	for i, want := range expected {
+		got := changes[i]
+		if got.ConfigName != want.config || got.Subject != want.subject || got.ChangeType != want.changeType || got.Description != want.description {
+			t.Errorf("Change %d: expected %+v, got %+v", i, want, got)
+		}
+	}
+}
+
+func TestCompareRuntimeConfigs_Unparseable(t *testing.T) {
+	oldMetadata := &metadata.TileMetadata{
+		RuntimeConfigs: []metadata.RuntimeConfig{{Name: "broken", RuntimeConfig: "addons: []"}},
+	}
+	newMetadata := &metadata.TileMetadata{
+		RuntimeConfigs: []metadata.RuntimeConfig{{Name: "broken", RuntimeConfig: "addons: [unterminated"}},
+	}
+
+	changes := CompareRuntimeConfigs(oldMetadata, newMetadata)
+	if len(changes) != 1 || changes[0].ChangeType != RuntimeConfigChanged {
+		t.Fatalf("Expected a single runtime config change, got %+v", changes)
+	}
+}
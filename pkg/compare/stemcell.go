@@ -0,0 +1,177 @@
+// ABOUTME: Stemcell criteria comparison between tile versions.
+// ABOUTME: Detects new stemcell lines and version requirement changes.
+package compare
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/malston/tile-diff/pkg/metadata"
+	"github.com/malston/tile-diff/pkg/version"
+)
+
+// CompareStemcells identifies stemcell criteria that differ between old and new metadata
+func CompareStemcells(oldMetadata, newMetadata *metadata.TileMetadata) []StemcellChange {
+	oldStemcells := buildStemcellMap(oldMetadata.Stemcells())
+	newStemcells := buildStemcellMap(newMetadata.Stemcells())
+
+	var changes []StemcellChange
+
+	for os, newLines := range newStemcells {
+		oldLines, exists := oldStemcells[os]
+		if !exists {
+			for _, newStemcell := range newLines {
+				changes = append(changes, stemcellAdded(newStemcell))
+			}
+			continue
+		}
+		changes = append(changes, compareStemcellLines(os, oldLines, newLines)...)
+	}
+
+	for os, oldLines := range oldStemcells {
+		if _, exists := newStemcells[os]; !exists {
+			for _, oldStemcell := range oldLines {
+				changes = append(changes, stemcellRemoved(oldStemcell))
+			}
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].OS != changes[j].OS {
+			return changes[i].OS < changes[j].OS
+		}
+		return stemcellChangeVersion(changes[i]) < stemcellChangeVersion(changes[j])
+	})
+
+	return changes
+}
+
+// compareStemcellLines compares the criteria for one OS, pairing old and new entries on the same
+// stemcell line. A single unpaired entry on each side is reported as a move to a new line.
+func compareStemcellLines(os string, oldLines, newLines []metadata.StemcellCriteria) []StemcellChange {
+	var changes []StemcellChange
+	var unpairedOld, unpairedNew []metadata.StemcellCriteria
+
+	paired := make(map[int]bool)
+	for _, newStemcell := range newLines {
+		match := -1
+		for i, oldStemcell := range oldLines {
+			if !paired[i] && stemcellLine(oldStemcell.Version) == stemcellLine(newStemcell.Version) {
+				match = i
+				break
+			}
+		}
+		if match < 0 {
+			unpairedNew = append(unpairedNew, newStemcell)
+			continue
+		}
+		paired[match] = true
+		if change, changed := stemcellVersionChange(os, oldLines[match], newStemcell); changed {
+			changes = append(changes, change)
+		}
+	}
+	for i, oldStemcell := range oldLines {
+		if !paired[i] {
+			unpairedOld = append(unpairedOld, oldStemcell)
+		}
+	}
+
+	if len(unpairedOld) == 1 && len(unpairedNew) == 1 {
+		change, _ := stemcellVersionChange(os, unpairedOld[0], unpairedNew[0])
+		return append(changes, change)
+	}
+	for _, newStemcell := range unpairedNew {
+		changes = append(changes, stemcellAdded(newStemcell))
+	}
+	for _, oldStemcell := range unpairedOld {
+		changes = append(changes, stemcellRemoved(oldStemcell))
+	}
+	return changes
+}
+
+// stemcellVersionChange describes a version change between old and new criteria for the same OS
+func stemcellVersionChange(os string, oldStemcell, newStemcell metadata.StemcellCriteria) (StemcellChange, bool) {
+	if oldStemcell.Version == newStemcell.Version {
+		return StemcellChange{}, false
+	}
+	return StemcellChange{
+		OS:          os,
+		ChangeType:  StemcellChanged,
+		OldVersion:  oldStemcell.Version,
+		NewVersion:  newStemcell.Version,
+		Description: describeStemcellVersionChange(os, oldStemcell.Version, newStemcell.Version),
+	}, true
+}
+
+func stemcellAdded(stemcell metadata.StemcellCriteria) StemcellChange {
+	return StemcellChange{
+		OS:          stemcell.OS,
+		ChangeType:  StemcellAdded,
+		NewVersion:  stemcell.Version,
+		Description: fmt.Sprintf("New stemcell line required: %s %s", stemcell.OS, stemcell.Version),
+	}
+}
+
+func stemcellRemoved(stemcell metadata.StemcellCriteria) StemcellChange {
+	return StemcellChange{
+		OS:          stemcell.OS,
+		ChangeType:  StemcellRemoved,
+		OldVersion:  stemcell.Version,
+		Description: fmt.Sprintf("Stemcell no longer required: %s %s", stemcell.OS, stemcell.Version),
+	}
+}
+
+// stemcellChangeVersion returns the version a change is ordered by within its OS
+func stemcellChangeVersion(change StemcellChange) string {
+	if change.NewVersion != "" {
+		return change.NewVersion
+	}
+	return change.OldVersion
+}
+
+// stemcellLine returns the stemcell line (major version) a criteria version belongs to
+func stemcellLine(stemcellVersion string) string {
+	parsed, err := version.Parse(stemcellVersion)
+	if err != nil {
+		return stemcellVersion
+	}
+	return fmt.Sprint(parsed.Major())
+}
+
+// IsStemcellCompatible reports whether an uploaded stemcell satisfies the tile's criteria.
+// Ops Manager requires the same OS and major version, with at least the required minor version.
+func IsStemcellCompatible(criteria metadata.StemcellCriteria, os, stemcellVersion string) bool {
+	if criteria.OS != os {
+		return false
+	}
+
+	required, err := version.Parse(criteria.Version)
+	if err != nil {
+		return criteria.Version == stemcellVersion
+	}
+	uploaded, err := version.Parse(stemcellVersion)
+	if err != nil {
+		return false
+	}
+
+	return uploaded.Major() == required.Major() && uploaded.Compare(required) >= 0
+}
+
+// buildStemcellMap groups stemcell criteria by operating system, keeping every line declared for it
+func buildStemcellMap(stemcells []metadata.StemcellCriteria) map[string][]metadata.StemcellCriteria {
+	stemcellMap := make(map[string][]metadata.StemcellCriteria, len(stemcells))
+	for _, stemcell := range stemcells {
+		stemcellMap[stemcell.OS] = append(stemcellMap[stemcell.OS], stemcell)
+	}
+	return stemcellMap
+}
+
+// describeStemcellVersionChange explains whether a version change needs a new stemcell line
+func describeStemcellVersionChange(os, oldVersion, newVersion string) string {
+	oldParsed, oldErr := version.Parse(oldVersion)
+	newParsed, newErr := version.Parse(newVersion)
+	if oldErr == nil && newErr == nil && oldParsed.Major() != newParsed.Major() {
+		return fmt.Sprintf("New stemcell line required: %s %s (was %s)", os, newVersion, oldVersion)
+	}
+	return fmt.Sprintf("Stemcell version changed from %s to %s", oldVersion, newVersion)
+}
@@ -0,0 +1,122 @@
+// ABOUTME: Unit tests for stemcell criteria comparison.
+// ABOUTME: Validates detection of stemcell line changes and compatibility rules.
+package compare
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/malston/tile-diff/pkg/metadata"
+)
+
+func TestCompareStemcells(t *testing.T) {
+	oldMetadata := &metadata.TileMetadata{
+		StemcellCriteria: metadata.StemcellCriteria{OS: "ubuntu-jammy", Version: "1.456"},
+		AdditionalStemcellsCriteria: []metadata.StemcellCriteria{
+			{OS: "ubuntu-xenial", Version: "621.900"},
+		},
+	}
+	newMetadata := &metadata.TileMetadata{
+		StemcellCriteria: metadata.StemcellCriteria{OS: "ubuntu-jammy", Version: "1.500"},
+		AdditionalStemcellsCriteria: []metadata.StemcellCriteria{
+			{OS: "windows2019", Version: "2019.70"},
+		},
+	}
+
+	changes := CompareStemcells(oldMetadata, newMetadata)
+	if len(changes) != 3 {
+		t.Fatalf("Expected 3 stemcell changes, got %d", len(changes))
+	}
+
+	// Sorted by OS
+	if changes[0].OS != "ubuntu-jammy" || changes[0].ChangeType != StemcellChanged {
+		t.Errorf("Expected ubuntu-jammy change first, got %s (%s)", changes[0].OS, changes[0].ChangeType)
+	}
+	if changes[0].OldVersion != "1.456" || changes[0].NewVersion != "1.500" {
+		t.Errorf("Expected 1.456 -> 1.500, got %s -> %s", changes[0].OldVersion, changes[0].NewVersion)
+	}
+	if changes[1].OS != "ubuntu-xenial" || changes[1].ChangeType != StemcellRemoved {
+		t.Errorf("Expected ubuntu-xenial removal, got %s (%s)", changes[1].OS, changes[1].ChangeType)
+	}
+	if changes[2].OS != "windows2019" || changes[2].ChangeType != StemcellAdded {
+		t.Errorf("Expected windows2019 addition, got %s (%s)", changes[2].OS, changes[2].ChangeType)
+	}
+}
+
+func TestCompareStemcellsNewLine(t *testing.T) {
+	oldMetadata := &metadata.TileMetadata{
+		StemcellCriteria: metadata.StemcellCriteria{OS: "ubuntu-jammy", Version: "1.456"},
+	}
+	newMetadata := &metadata.TileMetadata{
+		StemcellCriteria: metadata.StemcellCriteria{OS: "ubuntu-jammy", Version: "2.10"},
+	}
+
+	changes := CompareStemcells(oldMetadata, newMetadata)
+	if len(changes) != 1 {
+		t.Fatalf("Expected 1 stemcell change, got %d", len(changes))
+	}
+	if !strings.Contains(changes[0].Description, "New stemcell line") {
+		t.Errorf("Expected major version bump to require a new stemcell line, got '%s'", changes[0].Description)
+	}
+}
+
+func TestCompareStemcellsSameOSTwice(t *testing.T) {
+	oldMetadata := &metadata.TileMetadata{
+		StemcellCriteria: metadata.StemcellCriteria{OS: "ubuntu-jammy", Version: "1.456"},
+		AdditionalStemcellsCriteria: []metadata.StemcellCriteria{
+			{OS: "ubuntu-jammy", Version: "2.10"},
+		},
+	}
+	newMetadata := &metadata.TileMetadata{
+		StemcellCriteria: metadata.StemcellCriteria{OS: "ubuntu-jammy", Version: "1.500"},
+		AdditionalStemcellsCriteria: []metadata.StemcellCriteria{
+			{OS: "ubuntu-jammy", Version: "2.10"},
+			{OS: "ubuntu-jammy", Version: "3.5"},
+		},
+	}
+
+	changes := CompareStemcells(oldMetadata, newMetadata)
+	if len(changes) != 2 {
+		t.Fatalf("Expected 2 stemcell changes, got %d: %+v", len(changes), changes)
+	}
+	if changes[0].ChangeType != StemcellChanged || changes[0].OldVersion != "1.456" || changes[0].NewVersion != "1.500" {
+		t.Errorf("Expected 1.456 -> 1.500 change, got %+v", changes[0])
+	}
+	if changes[1].ChangeType != StemcellAdded || changes[1].NewVersion != "3.5" {
+		t.Errorf("Expected ubuntu-jammy 3.5 addition, got %+v", changes[1])
+	}
+}
+
+func TestCompareStemcellsUnchanged(t *testing.T) {
+	md := &metadata.TileMetadata{
+		StemcellCriteria: metadata.StemcellCriteria{OS: "ubuntu-jammy", Version: "1.500"},
+	}
+
+	changes := CompareStemcells(md, md)
+	if len(changes) != 0 {
+		t.Errorf("Expected no stemcell changes, got %d", len(changes))
+	}
+}
+
+func TestIsStemcellCompatible(t *testing.T) {
+	criteria := metadata.StemcellCriteria{OS: "ubuntu-jammy", Version: "1.500"}
+
+	tests := []struct {
+		os      string
+		version string
+		want    bool
+	}{
+		{"ubuntu-jammy", "1.500", true},
+		{"ubuntu-jammy", "1.512", true},
+		{"ubuntu-jammy", "1.456", false},
+		{"ubuntu-jammy", "2.1", false},
+		{"ubuntu-xenial", "1.500", false},
+	}
+
+	for _, tt := range tests {
+		got := IsStemcellCompatible(criteria, tt.os, tt.version)
+		if got != tt.want {
+			t.Errorf("IsStemcellCompatible(%s %s) = %v, want %v", tt.os, tt.version, got, tt.want)
+		}
+	}
+}
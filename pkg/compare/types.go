@@ -8,10 +8,33 @@ import "github.com/malston/tile-diff/pkg/metadata"
 type ChangeType string
 
 const (
-	PropertyAdded      ChangeType = "added"
-	PropertyRemoved    ChangeType = "removed"
-	TypeChanged        ChangeType = "type_changed"
-	OptionalityChanged ChangeType = "optionality_changed"
+	PropertyAdded        ChangeType = "added"
+	PropertyRemoved      ChangeType = "removed"
+	TypeChanged          ChangeType = "type_changed"
+	OptionalityChanged   ChangeType = "optionality_changed"
+	StemcellAdded        ChangeType = "stemcell_added"
+	StemcellRemoved      ChangeType = "stemcell_removed"
+	StemcellChanged      ChangeType = "stemcell_changed"
+	StemcellMissing      ChangeType = "stemcell_missing"
+	ReleaseAdded         ChangeType = "release_added"
+	ReleaseRemoved       ChangeType = "release_removed"
+	ReleaseUpdated       ChangeType = "release_updated"
+	DependencyAdded      ChangeType = "dependency_added"
+	DependencyRemoved    ChangeType = "dependency_removed"
+	DependencyChanged    ChangeType = "dependency_changed"
+	DependencyUnmet      ChangeType = "dependency_unmet"
+	ErrandAdded          ChangeType = "errand_added"
+	ErrandRemoved        ChangeType = "errand_removed"
+	ErrandChanged        ChangeType = "errand_changed"
+	ErrandEnabled        ChangeType = "errand_enabled"
+	FormAdded            ChangeType = "form_added"
+	FormRemoved          ChangeType = "form_removed"
+	VariableAdded        ChangeType = "variable_added"
+	VariableRemoved      ChangeType = "variable_removed"
+	VariableChanged      ChangeType = "variable_changed"
+	RuntimeConfigAdded   ChangeType = "runtime_config_added"
+	RuntimeConfigRemoved ChangeType = "runtime_config_removed"
+	RuntimeConfigChanged ChangeType = "runtime_config_changed"
 )
 
 // ComparisonResult represents a single property difference between versions
@@ -21,6 +44,77 @@ type ComparisonResult struct {
 	OldProperty  *metadata.PropertyBlueprint
 	NewProperty  *metadata.PropertyBlueprint
 	Description  string
+
+	// Ops Manager form placement, when the tile exposes the property on a form
+	FormName            string
+	FormLabel           string
+	PropertyLabel       string
+	PropertyDescription string
+}
+
+// StemcellChange represents a difference in the stemcell criteria between versions
+type StemcellChange struct {
+	OS          string
+	ChangeType  ChangeType
+	OldVersion  string
+	NewVersion  string
+	Description string
+}
+
+// ReleaseChange represents a difference in a bundled BOSH release between versions
+type ReleaseChange struct {
+	Name        string
+	ChangeType  ChangeType
+	OldVersion  string
+	NewVersion  string
+	File        string
+	FileMissing bool
+	Description string
+}
+
+// DependencyChange represents a difference in a required product version between versions
+type DependencyChange struct {
+	Name          string
+	ChangeType    ChangeType
+	OldConstraint string
+	NewConstraint string
+	Description   string
+}
+
+// ErrandChange represents a difference in a post-deploy or pre-delete errand between versions
+type ErrandChange struct {
+	Name        string
+	Lifecycle   string
+	ChangeType  ChangeType
+	OldDefault  string
+	NewDefault  string
+	Description string
+}
+
+// FormChange represents an Ops Manager form tab added or removed between versions
+type FormChange struct {
+	Name        string
+	Label       string
+	ChangeType  ChangeType
+	Description string
+}
+
+// VariableChange represents a difference in a generated credential between versions
+type VariableChange struct {
+	Name           string
+	Type           string
+	ChangeType     ChangeType
+	ChangedOptions []string
+	RotationRisk   bool
+	Description    string
+}
+
+// RuntimeConfigChange represents a difference in a runtime config shipped by the tile
+type RuntimeConfigChange struct {
+	ConfigName  string
+	Subject     string
+	ChangeType  ChangeType
+	Description string
 }
 
 // ComparisonResults holds all comparison results
@@ -28,6 +122,13 @@ type ComparisonResults struct {
 	Added            []ComparisonResult
 	Removed          []ComparisonResult
 	Changed          []ComparisonResult
+	Stemcells        []StemcellChange
+	Releases         []ReleaseChange
+	Dependencies     []DependencyChange
+	Errands          []ErrandChange
+	Forms            []FormChange
+	Variables        []VariableChange
+	RuntimeConfigs   []RuntimeConfigChange
 	TotalOldProps    int
 	TotalNewProps    int
 	ConfigurableOnly bool
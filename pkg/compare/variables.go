@@ -0,0 +1,131 @@
+// ABOUTME: Credential variable comparison between tile versions.
+// ABOUTME: Detects added, removed, and re-optioned variables and flags likely credential rotations.
+package compare
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/malston/tile-diff/pkg/metadata"
+)
+
+// rotationOptions are certificate options whose change causes CredHub to regenerate the credential
+var rotationOptions = map[string]bool{
+	"alternative_names":  true,
+	"ca":                 true,
+	"common_name":        true,
+	"extended_key_usage": true,
+	"is_ca":              true,
+	"key_usage":          true,
+}
+
+// CompareVariables identifies credential variables that were added, removed, or changed
+func CompareVariables(oldMetadata, newMetadata *metadata.TileMetadata) []VariableChange {
+	oldVars := buildVariableMap(oldMetadata.Variables)
+	newVars := buildVariableMap(newMetadata.Variables)
+
+	var changes []VariableChange
+
+	for name, newVar := range newVars {
+		oldVar, exists := oldVars[name]
+		if !exists {
+			changes = append(changes, VariableChange{
+				Name:         name,
+				Type:         newVar.Type,
+				ChangeType:   VariableAdded,
+				RotationRisk: isCertificate(newVar),
+				Description:  fmt.Sprintf("New %s variable", describeVariable(newVar)),
+			})
+			continue
+		}
+
+		if oldVar.Type != newVar.Type {
+			changes = append(changes, VariableChange{
+				Name:         name,
+				Type:         newVar.Type,
+				ChangeType:   VariableChanged,
+				RotationRisk: true,
+				Description:  fmt.Sprintf("Type changed from %s to %s", oldVar.Type, newVar.Type),
+			})
+			continue
+		}
+
+		changedOptions := diffOptions(oldVar.Options, newVar.Options)
+		if len(changedOptions) == 0 {
+			continue
+		}
+
+		rotation := false
+		for _, option := range changedOptions {
+			if rotationOptions[option] {
+				rotation = true
+			}
+		}
+		changes = append(changes, VariableChange{
+			Name:           name,
+			Type:           newVar.Type,
+			ChangeType:     VariableChanged,
+			ChangedOptions: changedOptions,
+			RotationRisk:   rotation,
+			Description:    fmt.Sprintf("Options changed: %s", strings.Join(changedOptions, ", ")),
+		})
+	}
+
+	for name, oldVar := range oldVars {
+		if _, exists := newVars[name]; !exists {
+			changes = append(changes, VariableChange{
+				Name:        name,
+				Type:        oldVar.Type,
+				ChangeType:  VariableRemoved,
+				Description: fmt.Sprintf("Removed %s variable", describeVariable(oldVar)),
+			})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Name < changes[j].Name
+	})
+
+	return changes
+}
+
+// diffOptions returns the sorted names of options that differ between two option sets
+func diffOptions(oldOptions, newOptions map[string]interface{}) []string {
+	var changed []string
+	for key, newValue := range newOptions {
+		if oldValue, exists := oldOptions[key]; !exists || !reflect.DeepEqual(oldValue, newValue) {
+			changed = append(changed, key)
+		}
+	}
+	for key := range oldOptions {
+		if _, exists := newOptions[key]; !exists {
+			changed = append(changed, key)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+// isCertificate reports whether the variable generates a certificate or CA
+func isCertificate(variable metadata.Variable) bool {
+	return variable.Type == "certificate"
+}
+
+// describeVariable returns a short description such as "certificate (CA)"
+func describeVariable(variable metadata.Variable) string {
+	if isCA, ok := variable.Options["is_ca"].(bool); ok && isCA {
+		return fmt.Sprintf("%s (CA)", variable.Type)
+	}
+	return variable.Type
+}
+
+// buildVariableMap keys variables by name
+func buildVariableMap(variables []metadata.Variable) map[string]metadata.Variable {
+	variableMap := make(map[string]metadata.Variable, len(variables))
+	for _, variable := range variables {
+		variableMap[variable.Name] = variable
+	}
+	return variableMap
+}
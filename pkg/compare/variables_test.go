@@ -0,0 +1,79 @@
+// ABOUTME: Unit tests for credential variable comparison.
+// ABOUTME: Validates change detection and credential rotation risk flagging.
+package compare
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/malston/tile-diff/pkg/metadata"
+)
+
+func TestCompareVariables(t *testing.T) {
+	oldMetadata := &metadata.TileMetadata{
+		Variables: []metadata.Variable{
+			{Name: "gorouter-cert", Type: "certificate", Options: map[string]interface{}{
+				"ca":                "/services/intermediate_tls_ca",
+				"alternative_names": []interface{}{"*.sys.example.com"},
+			}},
+			{Name: "uaa-admin-password", Type: "password", Options: map[string]interface{}{"length": 20}},
+			{Name: "legacy-token", Type: "password"},
+			{Name: "diego-ca", Type: "certificate", Options: map[string]interface{}{"is_ca": true}},
+		},
+	}
+	newMetadata := &metadata.TileMetadata{
+		Variables: []metadata.Variable{
+			{Name: "gorouter-cert", Type: "certificate", Options: map[string]interface{}{
+				"ca":                "/services/intermediate_tls_ca",
+				"alternative_names": []interface{}{"*.sys.example.com", "*.apps.example.com"},
+			}},
+			{Name: "uaa-admin-password", Type: "password", Options: map[string]interface{}{"length": 32}},
+			{Name: "diego-ca", Type: "certificate", Options: map[string]interface{}{"is_ca": true}},
+			{Name: "loggregator-ca", Type: "certificate", Options: map[string]interface{}{"is_ca": true, "common_name": "loggregatorCA"}},
+		},
+	}
+
+	changes := CompareVariables(oldMetadata, newMetadata)
+	if len(changes) != 4 {
+		t.Fatalf("Expected 4 variable changes, got %d", len(changes))
+	}
+
+	expected := []struct {
+		name       string
+		changeType ChangeType
+		rotation   bool
+	}{
+		{"gorouter-cert", VariableChanged, true},
+		{"legacy-token", VariableRemoved, false},
+		{"loggregator-ca", VariableAdded, true},
+		{"uaa-admin-password", VariableChanged, false},
+	}
+	for i, want := range expected {
+		got := changes[i]
+		if got.Name != want.name || got.ChangeType != want.changeType || got.RotationRisk != want.rotation {
+			t.Errorf("Change %d: expected %s (%s, rotation %v), got %s (%s, rotation %v)",
+				i, want.name, want.changeType, want.rotation, got.Name, got.ChangeType, got.RotationRisk)
+		}
+	}
+
+	if !reflect.DeepEqual(changes[0].ChangedOptions, []string{"alternative_names"}) {
+		t.Errorf("Expected alternative_names changed, got %v", changes[0].ChangedOptions)
+	}
+	if changes[2].Description != "New certificate (CA) variable" {
+		t.Errorf("Expected CA description, got '%s'", changes[2].Description)
+	}
+}
+
+func TestCompareVariables_TypeChange(t *testing.T) {
+	oldMetadata := &metadata.TileMetadata{
+		Variables: []metadata.Variable{{Name: "internal-tls", Type: "rsa"}},
+	}
+	newMetadata := &metadata.TileMetadata{
+		Variables: []metadata.Variable{{Name: "internal-tls", Type: "certificate"}},
+	}
+
+	changes := CompareVariables(oldMetadata, newMetadata)
+	if len(changes) != 1 || !changes[0].RotationRisk {
+		t.Fatalf("Expected a single rotation-risk change, got %+v", changes)
+	}
+}
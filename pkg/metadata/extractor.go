@@ -1,5 +1,5 @@
-// ABOUTME: Extracts metadata.yml from .pivotal ZIP archives.
-// ABOUTME: Provides functions to read and parse tile metadata files.
+// ABOUTME: Extracts the metadata document from .pivotal ZIP archives and extracted tile directories.
+// ABOUTME: Discovers the document by content so non-standard file names and extra YAML files are handled.
 package metadata
 
 import (
@@ -7,32 +7,152 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
 )
 
-// ExtractMetadata extracts metadata/metadata.yml from a .pivotal ZIP archive
+// standardMetadataPath is the conventional location of the metadata document in a tile
+const standardMetadataPath = "metadata/metadata.yml"
+
+// metadataCandidate is a YAML file in a tile's metadata directory
+type metadataCandidate struct {
+	Name    string
+	Content []byte
+}
+
+// ExtractMetadata extracts the metadata document from a .pivotal ZIP archive
 func ExtractMetadata(zipData []byte) ([]byte, error) {
-	reader, err := zip.NewReader(bytes.NewReader(zipData), int64(len(zipData)))
+	return ExtractMetadataFromReader(bytes.NewReader(zipData), int64(len(zipData)))
+}
+
+// ExtractMetadataFromReader extracts the metadata document from a .pivotal ZIP archive of the given size
+func ExtractMetadataFromReader(r io.ReaderAt, size int64) ([]byte, error) {
+	reader, err := zip.NewReader(r, size)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read ZIP archive: %w", err)
 	}
 
-	// Look for metadata/metadata.yml
+	return extractFromZip(reader)
+}
+
+// ExtractMetadataFromDirectory extracts the metadata document from an extracted tile directory
+func ExtractMetadataFromDirectory(dir string) ([]byte, error) {
+	entries, err := os.ReadDir(filepath.Join(dir, "metadata"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read metadata directory: %w", err)
+	}
+
+	var candidates []metadataCandidate
+	for _, entry := range entries {
+		name := path.Join("metadata", entry.Name())
+		if entry.IsDir() || !isYAMLFile(name) {
+			continue
+		}
+
+		content, err := os.ReadFile(filepath.Join(dir, "metadata", entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", name, err)
+		}
+		candidates = append(candidates, metadataCandidate{Name: name, Content: content})
+	}
+
+	return selectMetadataDocument(candidates)
+}
+
+// extractFromZip reads the metadata document from an opened archive
+func extractFromZip(reader *zip.Reader) ([]byte, error) {
+	var candidates []metadataCandidate
 	for _, f := range reader.File {
-		if f.Name == "metadata/metadata.yml" {
-			rc, err := f.Open()
-			if err != nil {
-				return nil, fmt.Errorf("failed to open metadata.yml: %w", err)
-			}
-			defer rc.Close()
-
-			content, err := io.ReadAll(rc)
-			if err != nil {
-				return nil, fmt.Errorf("failed to read metadata.yml: %w", err)
-			}
-
-			return content, nil
+		if path.Dir(f.Name) != "metadata" || !isYAMLFile(f.Name) {
+			continue
 		}
+
+		content, err := readZipFile(f)
+		if err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, metadataCandidate{Name: f.Name, Content: content})
 	}
 
-	return nil, fmt.Errorf("metadata/metadata.yml not found in archive")
+	return selectMetadataDocument(candidates)
+}
+
+// readZipFile reads the full contents of an archive entry
+func readZipFile(f *zip.File) ([]byte, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", f.Name, err)
+	}
+	defer rc.Close()
+
+	content, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", f.Name, err)
+	}
+
+	return content, nil
+}
+
+// selectMetadataDocument picks the one candidate that looks like tile metadata;
+// metadata/metadata.yml wins when it qualifies, otherwise several matches are an error
+func selectMetadataDocument(candidates []metadataCandidate) ([]byte, error) {
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("no YAML files found in metadata/")
+	}
+
+	var matches []metadataCandidate
+	for _, candidate := range candidates {
+		if !looksLikeMetadata(candidate.Content) {
+			continue
+		}
+		if candidate.Name == standardMetadataPath {
+			return candidate.Content, nil
+		}
+		matches = append(matches, candidate)
+	}
+
+	switch len(matches) {
+	case 1:
+		return matches[0].Content, nil
+	case 0:
+		return nil, fmt.Errorf("no metadata document found in metadata/ (candidates: %s)", candidateNames(candidates))
+	default:
+		return nil, fmt.Errorf("multiple metadata documents found: %s", candidateNames(matches))
+	}
+}
+
+// looksLikeMetadata reports whether a YAML document has the top-level property_blueprints or name of tile
+// metadata; third-party tiles do not always set product_version
+func looksLikeMetadata(content []byte) bool {
+	var doc map[string]yaml.Node
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return false
+	}
+
+	if _, ok := doc["property_blueprints"]; ok {
+		return true
+	}
+	name, ok := doc["name"]
+	return ok && name.Kind == yaml.ScalarNode && name.Value != ""
+}
+
+// candidateNames lists candidate file names in sorted order
+func candidateNames(candidates []metadataCandidate) string {
+	names := make([]string, len(candidates))
+	for i, candidate := range candidates {
+		names[i] = candidate.Name
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// isYAMLFile reports whether name has a YAML extension
+func isYAMLFile(name string) bool {
+	ext := strings.ToLower(path.Ext(name))
+	return ext == ".yml" || ext == ".yaml"
 }
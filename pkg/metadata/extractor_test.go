@@ -3,6 +3,10 @@ package metadata
 import (
 	"archive/zip"
 	"bytes"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -124,3 +128,113 @@ func TestParseMetadataInvalid(t *testing.T) {
 		t.Error("Expected error for invalid YAML")
 	}
 }
+
+func TestExtractMetadataFromReader(t *testing.T) {
+	buf := new(bytes.Buffer)
+	w := zip.NewWriter(buf)
+	f, err := w.Create("metadata/metadata.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.Write([]byte("property_blueprints: []\n")); err != nil {
+		t.Fatal(err)
+	}
+	w.Close()
+
+	content, err := ExtractMetadataFromReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("ExtractMetadataFromReader failed: %v", err)
+	}
+	if !bytes.Contains(content, []byte("property_blueprints")) {
+		t.Errorf("Unexpected metadata content: %s", content)
+	}
+}
+
+// zipDirectory packs a fixture tile layout into an in-memory .pivotal archive
+func zipDirectory(t *testing.T, dir string) []byte {
+	t.Helper()
+
+	buf := new(bytes.Buffer)
+	w := zip.NewWriter(buf)
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		f, err := w.Create(filepath.ToSlash(rel))
+		if err != nil {
+			return err
+		}
+		_, err = f.Write(content)
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestExtractMetadata_Layouts(t *testing.T) {
+	tests := []struct {
+		layout      string
+		contains    string
+		errContains []string
+	}{
+		{layout: "standard", contains: "standard_property"},
+		{layout: "product-named", contains: "product_named_property"},
+		{layout: "name-only", contains: "legacy-tile"},
+		{layout: "third-party", contains: "acme-monitoring"},
+		{layout: "extra-yaml", contains: "extra_yaml_property"},
+		{layout: "standard-with-extra", contains: "standard_with_extra_property"},
+		{
+			layout:      "ambiguous",
+			errContains: []string{"multiple metadata documents", "metadata/p-redis-windows.yml, metadata/p-redis.yml"},
+		},
+		{
+			layout:      "no-metadata",
+			errContains: []string{"no metadata document found", "metadata/notes.yml"},
+		},
+	}
+
+	for _, tt := range tests {
+		dir := filepath.Join("testdata", "layouts", tt.layout)
+		extractors := map[string]func() ([]byte, error){
+			"zip":       func() ([]byte, error) { return ExtractMetadata(zipDirectory(t, dir)) },
+			"directory": func() ([]byte, error) { return ExtractMetadataFromDirectory(dir) },
+		}
+
+		for source, extract := range extractors {
+			t.Run(tt.layout+"/"+source, func(t *testing.T) {
+				content, err := extract()
+				if tt.errContains != nil {
+					if err == nil {
+						t.Fatal("Expected error")
+					}
+					for _, want := range tt.errContains {
+						if !strings.Contains(err.Error(), want) {
+							t.Errorf("Expected error to contain %q, got: %v", want, err)
+						}
+					}
+					return
+				}
+
+				if err != nil {
+					t.Fatalf("Extraction failed: %v", err)
+				}
+				if !bytes.Contains(content, []byte(tt.contains)) {
+					t.Errorf("Expected document containing %q, got:\n%s", tt.contains, content)
+				}
+			})
+		}
+	}
+}
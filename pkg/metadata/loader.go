@@ -3,20 +3,22 @@
 package metadata
 
 import (
+	"archive/zip"
 	"fmt"
 	"os"
 )
 
 // LoadFromFile loads and parses metadata from a .pivotal file
 func LoadFromFile(path string) (*TileMetadata, error) {
-	// Read the .pivotal file
-	zipData, err := os.ReadFile(path)
+	// Open the .pivotal file; only the central directory and metadata entry are read
+	archive, err := zip.OpenReader(path)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read file %s: %w", path, err)
+		return nil, fmt.Errorf("failed to open file %s: %w", path, err)
 	}
+	defer archive.Close()
 
-	// Extract metadata.yml
-	yamlData, err := ExtractMetadata(zipData)
+	// Extract the metadata document
+	yamlData, err := extractFromZip(&archive.Reader)
 	if err != nil {
 		return nil, fmt.Errorf("failed to extract metadata from %s: %w", path, err)
 	}
@@ -29,3 +31,18 @@ func LoadFromFile(path string) (*TileMetadata, error) {
 
 	return metadata, nil
 }
+
+// LoadFromYAMLFile loads and parses metadata already extracted from a tile
+func LoadFromYAMLFile(path string) (*TileMetadata, error) {
+	yamlData, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %s: %w", path, err)
+	}
+
+	metadata, err := ParseMetadata(yamlData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse metadata from %s: %w", path, err)
+	}
+
+	return metadata, nil
+}
@@ -3,8 +3,10 @@ package metadata
 import (
 	"archive/zip"
 	"bytes"
+	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
 	"testing"
 )
 
@@ -65,3 +67,78 @@ func TestLoadFromFileNotFound(t *testing.T) {
 		t.Error("Expected error for nonexistent file")
 	}
 }
+
+// writeTestTile writes a .pivotal file containing metadata plus an uncompressed release of padding bytes
+func writeTestTile(tb testing.TB, path string, padding int64) {
+	tb.Helper()
+
+	file, err := os.Create(path)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	defer file.Close()
+
+	w := zip.NewWriter(file)
+	f, err := w.Create("metadata/metadata.yml")
+	if err != nil {
+		tb.Fatal(err)
+	}
+	if _, err := f.Write([]byte("property_blueprints:\n  - name: test_property\n    type: string\n")); err != nil {
+		tb.Fatal(err)
+	}
+
+	release, err := w.CreateHeader(&zip.FileHeader{Name: "releases/padding.tgz", Method: zip.Store})
+	if err != nil {
+		tb.Fatal(err)
+	}
+	chunk := make([]byte, 1<<20)
+	for written := int64(0); written < padding; written += int64(len(chunk)) {
+		if _, err := release.Write(chunk); err != nil {
+			tb.Fatal(err)
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		tb.Fatal(err)
+	}
+}
+
+func TestLoadFromFile_MemoryIndependentOfTileSize(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping large tile test in short mode")
+	}
+
+	path := filepath.Join(t.TempDir(), "large.pivotal")
+	writeTestTile(t, path, 64<<20)
+
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+
+	if _, err := LoadFromFile(path); err != nil {
+		t.Fatalf("LoadFromFile failed: %v", err)
+	}
+
+	runtime.ReadMemStats(&after)
+	allocated := after.TotalAlloc - before.TotalAlloc
+	if allocated > 4<<20 {
+		t.Errorf("Expected loading a 64 MB tile to allocate under 4 MB, allocated %d bytes", allocated)
+	}
+}
+
+func BenchmarkLoadFromFile(b *testing.B) {
+	for _, size := range []int64{1 << 20, 64 << 20, 256 << 20} {
+		b.Run(fmt.Sprintf("%dMB", size>>20), func(b *testing.B) {
+			path := filepath.Join(b.TempDir(), "tile.pivotal")
+			writeTestTile(b, path, size)
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := LoadFromFile(path); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
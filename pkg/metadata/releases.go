@@ -0,0 +1,28 @@
+// ABOUTME: Lists BOSH release files bundled inside .pivotal archives.
+// ABOUTME: Used to confirm releases declared in metadata are present in the tile.
+package metadata
+
+import (
+	"archive/zip"
+	"fmt"
+	"path"
+	"strings"
+)
+
+// ListReleaseFiles returns the file names found in the releases/ directory of a .pivotal file
+func ListReleaseFiles(tilePath string) ([]string, error) {
+	reader, err := zip.OpenReader(tilePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", tilePath, err)
+	}
+	defer reader.Close()
+
+	var files []string
+	for _, f := range reader.File {
+		if strings.HasPrefix(f.Name, "releases/") && !f.FileInfo().IsDir() {
+			files = append(files, path.Base(f.Name))
+		}
+	}
+
+	return files, nil
+}
@@ -0,0 +1,51 @@
+// ABOUTME: Unit tests for listing BOSH release files in tile archives.
+// ABOUTME: Validates discovery of files under the releases/ directory.
+package metadata
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestListReleaseFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+	pivotalPath := filepath.Join(tmpDir, "test.pivotal")
+
+	out, err := os.Create(pivotalPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := zip.NewWriter(out)
+	for _, name := range []string{
+		"metadata/metadata.yml",
+		"releases/capi-1.2.3.tgz",
+		"releases/diego-2.100.0.tgz",
+	} {
+		if _, err := w.Create(name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	w.Close()
+	out.Close()
+
+	files, err := ListReleaseFiles(pivotalPath)
+	if err != nil {
+		t.Fatalf("ListReleaseFiles failed: %v", err)
+	}
+
+	if len(files) != 2 {
+		t.Fatalf("Expected 2 release files, got %d", len(files))
+	}
+	if files[0] != "capi-1.2.3.tgz" || files[1] != "diego-2.100.0.tgz" {
+		t.Errorf("Unexpected release files: %v", files)
+	}
+}
+
+func TestListReleaseFilesNotFound(t *testing.T) {
+	_, err := ListReleaseFiles("/nonexistent/file.pivotal")
+	if err == nil {
+		t.Error("Expected error for nonexistent file")
+	}
+}
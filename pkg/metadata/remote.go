@@ -0,0 +1,159 @@
+// ABOUTME: Reads tile metadata from a remote .pivotal file using HTTP Range requests.
+// ABOUTME: Fetches only the ZIP central directory and metadata entry instead of the whole tile.
+package metadata
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// rangeReadAhead is the minimum number of bytes fetched per request, so the many small
+// reads made while parsing the central directory are served from one response
+const rangeReadAhead = 256 << 10
+
+// HTTPRangeReader implements io.ReaderAt over HTTP Range requests
+type HTTPRangeReader struct {
+	client *http.Client
+	url    string
+	size   int64
+
+	mu       sync.Mutex
+	block    []byte
+	blockOff int64
+}
+
+// NewHTTPRangeReader creates a reader for url, determining its size with a one-byte range request
+func NewHTTPRangeReader(client *http.Client, url string) (*HTTPRangeReader, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	r := &HTTPRangeReader{client: client, url: url}
+
+	resp, err := r.get(0, 0)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	size, err := parseContentRangeSize(resp.Header.Get("Content-Range"))
+	if err != nil {
+		return nil, err
+	}
+	r.size = size
+
+	return r, nil
+}
+
+// Size returns the total size of the remote file
+func (r *HTTPRangeReader) Size() int64 {
+	return r.size
+}
+
+// ReadAt reads len(p) bytes starting at off, fetching at least rangeReadAhead bytes per request
+func (r *HTTPRangeReader) ReadAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, fmt.Errorf("negative offset %d", off)
+	}
+	if off >= r.size {
+		return 0, io.EOF
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	end := off + int64(len(p))
+	if end > r.size {
+		end = r.size
+	}
+
+	if off < r.blockOff || end > r.blockOff+int64(len(r.block)) {
+		fetchEnd := end
+		if fetchEnd-off < rangeReadAhead {
+			fetchEnd = off + rangeReadAhead
+		}
+		if fetchEnd > r.size {
+			fetchEnd = r.size
+		}
+
+		block, err := r.fetch(off, fetchEnd-1)
+		if err != nil {
+			return 0, err
+		}
+		r.block = block
+		r.blockOff = off
+	}
+
+	n := copy(p, r.block[off-r.blockOff:end-r.blockOff])
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// fetch downloads the inclusive byte range [start, end]
+func (r *HTTPRangeReader) fetch(start, end int64) ([]byte, error) {
+	resp, err := r.get(start, end)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data := make([]byte, end-start+1)
+	if _, err := io.ReadFull(resp.Body, data); err != nil {
+		return nil, fmt.Errorf("failed to read bytes %d-%d: %w", start, end, err)
+	}
+	return data, nil
+}
+
+// get issues a range request and requires a 206 Partial Content response
+func (r *HTTPRangeReader) get(start, end int64) (*http.Response, error) {
+	req, err := http.NewRequest("GET", r.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", start, end))
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute range request: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusPartialContent {
+		resp.Body.Close()
+		if resp.StatusCode == http.StatusOK {
+			return nil, fmt.Errorf("server does not support range requests")
+		}
+		return nil, fmt.Errorf("range request failed with status %d", resp.StatusCode)
+	}
+
+	return resp, nil
+}
+
+// parseContentRangeSize extracts the total size from a header like "bytes 0-0/12345"
+func parseContentRangeSize(contentRange string) (int64, error) {
+	slash := strings.LastIndex(contentRange, "/")
+	if slash < 0 || contentRange[slash+1:] == "*" {
+		return 0, fmt.Errorf("cannot determine file size from Content-Range %q", contentRange)
+	}
+
+	size, err := strconv.ParseInt(contentRange[slash+1:], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid Content-Range %q: %w", contentRange, err)
+	}
+	return size, nil
+}
+
+// ExtractMetadataFromURL extracts the metadata document from a remote .pivotal file using range requests
+func ExtractMetadataFromURL(client *http.Client, url string) ([]byte, error) {
+	reader, err := NewHTTPRangeReader(client, url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open remote tile: %w", err)
+	}
+
+	return ExtractMetadataFromReader(reader, reader.Size())
+}
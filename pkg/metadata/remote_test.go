@@ -0,0 +1,104 @@
+// ABOUTME: Unit tests for reading tile metadata over HTTP Range requests.
+// ABOUTME: Serves a fixture tile from httptest and verifies only a small part is transferred.
+package metadata
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// serveTile starts a range-capable server for the tile at path and counts the bytes it sends
+func serveTile(t *testing.T, path string, sent *int64) *httptest.Server {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		counter := &countingWriter{ResponseWriter: w, sent: sent}
+		http.ServeContent(counter, r, "tile.pivotal", time.Time{}, bytes.NewReader(data))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+type countingWriter struct {
+	http.ResponseWriter
+	sent *int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	atomic.AddInt64(w.sent, int64(len(p)))
+	return w.ResponseWriter.Write(p)
+}
+
+func TestExtractMetadataFromURL(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tile.pivotal")
+	writeTestTile(t, path, 16<<20)
+
+	var sent int64
+	server := serveTile(t, path, &sent)
+
+	content, err := ExtractMetadataFromURL(server.Client(), server.URL)
+	if err != nil {
+		t.Fatalf("ExtractMetadataFromURL failed: %v", err)
+	}
+
+	metadata, err := ParseMetadata(content)
+	if err != nil {
+		t.Fatalf("ParseMetadata failed: %v", err)
+	}
+	if len(metadata.PropertyBlueprints) != 1 || metadata.PropertyBlueprints[0].Name != "test_property" {
+		t.Errorf("Unexpected metadata: %+v", metadata.PropertyBlueprints)
+	}
+
+	if sent > 1<<20 {
+		t.Errorf("Expected under 1 MB transferred for a 16 MB tile, sent %d bytes", sent)
+	}
+}
+
+func TestNewHTTPRangeReader_NoRangeSupport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("whole file"))
+	}))
+	defer server.Close()
+
+	if _, err := NewHTTPRangeReader(server.Client(), server.URL); err == nil {
+		t.Error("Expected error when server ignores Range header")
+	}
+}
+
+func TestHTTPRangeReader_ReadAt(t *testing.T) {
+	data := []byte("0123456789abcdefghij")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.ServeContent(w, r, "data", time.Time{}, bytes.NewReader(data))
+	}))
+	defer server.Close()
+
+	reader, err := NewHTTPRangeReader(server.Client(), server.URL)
+	if err != nil {
+		t.Fatalf("NewHTTPRangeReader failed: %v", err)
+	}
+	if reader.Size() != int64(len(data)) {
+		t.Fatalf("Expected size %d, got %d", len(data), reader.Size())
+	}
+
+	buf := make([]byte, 5)
+	n, err := reader.ReadAt(buf, 10)
+	if err != nil || string(buf[:n]) != "abcde" {
+		t.Errorf("Expected 'abcde', got %q (err %v)", buf[:n], err)
+	}
+
+	n, err = reader.ReadAt(buf, 18)
+	if n != 2 || err == nil || string(buf[:n]) != "ij" {
+		t.Errorf("Expected short read 'ij' with EOF, got %q (err %v)", buf[:n], err)
+	}
+}
@@ -0,0 +1,45 @@
+// ABOUTME: Defines runtime configs shipped in tile metadata and their embedded BOSH manifests.
+// ABOUTME: Parses the runtime_config YAML string into addons, jobs, and placement rules.
+package metadata
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// RuntimeConfig represents a BOSH runtime config the tile applies to the director
+type RuntimeConfig struct {
+	Name          string `yaml:"name"`
+	RuntimeConfig string `yaml:"runtime_config"`
+}
+
+// RuntimeConfigManifest represents the parsed runtime config manifest
+type RuntimeConfigManifest struct {
+	Releases []Release `yaml:"releases,omitempty"`
+	Addons   []Addon   `yaml:"addons,omitempty"`
+}
+
+// Addon represents a set of jobs colocated onto matching deployments
+type Addon struct {
+	Name    string                 `yaml:"name"`
+	Jobs    []AddonJob             `yaml:"jobs,omitempty"`
+	Include map[string]interface{} `yaml:"include,omitempty"`
+	Exclude map[string]interface{} `yaml:"exclude,omitempty"`
+}
+
+// AddonJob represents a job provided by an addon
+type AddonJob struct {
+	Name       string                 `yaml:"name"`
+	Release    string                 `yaml:"release"`
+	Properties map[string]interface{} `yaml:"properties,omitempty"`
+}
+
+// Manifest parses the embedded runtime config manifest
+func (r RuntimeConfig) Manifest() (*RuntimeConfigManifest, error) {
+	var manifest RuntimeConfigManifest
+	if err := yaml.Unmarshal([]byte(r.RuntimeConfig), &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse runtime config %s: %w", r.Name, err)
+	}
+	return &manifest, nil
+}
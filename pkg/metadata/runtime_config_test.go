@@ -0,0 +1,68 @@
+// ABOUTME: Unit tests for runtime config parsing.
+// ABOUTME: Validates extraction of addons, jobs, and placement rules from embedded manifests.
+package metadata
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestRuntimeConfigManifest(t *testing.T) {
+	yamlData := `
+runtime_configs:
+  - name: cf-dns-aliases
+    runtime_config: |
+      releases:
+        - name: bosh-dns-aliases
+          version: 0.0.4
+      addons:
+        - name: cf-dns-aliases
+          jobs:
+            - name: bosh-dns-aliases
+              release: bosh-dns-aliases
+              properties:
+                aliases:
+                  - domain: sql-db.service.cf.internal
+          include:
+            stemcell:
+              - os: ubuntu-jammy
+          exclude:
+            deployments: [p-bosh]
+`
+	var tm TileMetadata
+	if err := yaml.Unmarshal([]byte(yamlData), &tm); err != nil {
+		t.Fatalf("Failed to unmarshal: %v", err)
+	}
+
+	if len(tm.RuntimeConfigs) != 1 {
+		t.Fatalf("Expected 1 runtime config, got %d", len(tm.RuntimeConfigs))
+	}
+
+	manifest, err := tm.RuntimeConfigs[0].Manifest()
+	if err != nil {
+		t.Fatalf("Manifest failed: %v", err)
+	}
+
+	if len(manifest.Releases) != 1 || manifest.Releases[0].Version != "0.0.4" {
+		t.Errorf("Expected bosh-dns-aliases 0.0.4, got %+v", manifest.Releases)
+	}
+	if len(manifest.Addons) != 1 {
+		t.Fatalf("Expected 1 addon, got %d", len(manifest.Addons))
+	}
+	addon := manifest.Addons[0]
+	if len(addon.Jobs) != 1 || addon.Jobs[0].Release != "bosh-dns-aliases" {
+		t.Errorf("Expected bosh-dns-aliases job, got %+v", addon.Jobs)
+	}
+	if addon.Include["stemcell"] == nil || addon.Exclude["deployments"] == nil {
+		t.Errorf("Expected include and exclude rules, got %v / %v", addon.Include, addon.Exclude)
+	}
+}
+
+func TestRuntimeConfigManifest_Invalid(t *testing.T) {
+	config := RuntimeConfig{Name: "broken", RuntimeConfig: "addons: [unterminated"}
+
+	if _, err := config.Manifest(); err == nil {
+		t.Error("Expected error for invalid runtime config manifest")
+	}
+}
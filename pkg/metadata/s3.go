@@ -0,0 +1,246 @@
+// ABOUTME: Loads tile metadata from S3-compatible object storage such as AWS S3 or MinIO.
+// ABOUTME: Signs requests with AWS Signature Version 4 and reads .pivotal objects via range requests.
+package metadata
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// emptyPayloadHash is the SHA-256 of an empty request body
+const emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+// S3Config holds the endpoint and credentials used to read from an S3-compatible store
+type S3Config struct {
+	Endpoint        string
+	Region          string
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+}
+
+// S3ConfigFromEnv reads S3 settings from the standard AWS environment variables;
+// AWS_ENDPOINT_URL_S3 or AWS_ENDPOINT_URL selects an S3-compatible endpoint such as MinIO
+func S3ConfigFromEnv() S3Config {
+	config := S3Config{
+		Endpoint:        os.Getenv("AWS_ENDPOINT_URL_S3"),
+		Region:          os.Getenv("AWS_REGION"),
+		AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+	}
+	if config.Endpoint == "" {
+		config.Endpoint = os.Getenv("AWS_ENDPOINT_URL")
+	}
+	if config.Region == "" {
+		config.Region = os.Getenv("AWS_DEFAULT_REGION")
+	}
+	if config.Region == "" {
+		config.Region = "us-east-1"
+	}
+	if config.Endpoint == "" {
+		config.Endpoint = fmt.Sprintf("https://s3.%s.amazonaws.com", config.Region)
+	}
+	return config
+}
+
+// LoadFromS3 loads metadata from an s3://bucket/key URI using settings from the environment
+func LoadFromS3(uri string) (*TileMetadata, error) {
+	return LoadFromS3WithConfig(uri, S3ConfigFromEnv())
+}
+
+// LoadFromS3WithConfig loads metadata from an s3://bucket/key URI; .yml/.yaml objects are read
+// whole and any other object is treated as a .pivotal file and read via range requests
+func LoadFromS3WithConfig(uri string, config S3Config) (*TileMetadata, error) {
+	objectURL, err := s3ObjectURL(config.Endpoint, uri)
+	if err != nil {
+		return nil, err
+	}
+
+	client := &http.Client{Transport: &s3SigningTransport{config: config, base: http.DefaultTransport}}
+
+	var yamlData []byte
+	if ext := strings.ToLower(objectURL.Path); strings.HasSuffix(ext, ".yml") || strings.HasSuffix(ext, ".yaml") {
+		yamlData, err = getS3Object(client, objectURL.String())
+	} else {
+		yamlData, err = ExtractMetadataFromURL(client, objectURL.String())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", uri, err)
+	}
+
+	metadata, err := ParseMetadata(yamlData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse metadata from %s: %w", uri, err)
+	}
+
+	return metadata, nil
+}
+
+// s3ObjectURL converts s3://bucket/key into a path-style URL on the endpoint
+func s3ObjectURL(endpoint, uri string) (*url.URL, error) {
+	bucketAndKey := strings.TrimPrefix(uri, "s3://")
+	slash := strings.Index(bucketAndKey, "/")
+	if slash <= 0 || slash == len(bucketAndKey)-1 {
+		return nil, fmt.Errorf("invalid S3 URI %q: expected s3://bucket/key", uri)
+	}
+
+	base, err := url.Parse(strings.TrimSuffix(endpoint, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid S3 endpoint %q: %w", endpoint, err)
+	}
+
+	path := "/" + bucketAndKey
+	base.Path = base.Path + path
+	base.RawPath = strings.TrimSuffix(base.RawPath, "/") + escapeS3Path(path)
+	return base, nil
+}
+
+// getS3Object downloads a whole object
+func getS3Object(client *http.Client, objectURL string) ([]byte, error) {
+	resp, err := client.Get(objectURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	return io.ReadAll(resp.Body)
+}
+
+// s3SigningTransport signs each request with AWS Signature Version 4 when credentials are configured
+type s3SigningTransport struct {
+	config S3Config
+	base   http.RoundTripper
+}
+
+// RoundTrip signs a copy of the request and sends it
+func (t *s3SigningTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.config.AccessKeyID == "" {
+		return t.base.RoundTrip(req)
+	}
+
+	signed := req.Clone(req.Context())
+	signS3Request(signed, t.config, time.Now().UTC())
+	return t.base.RoundTrip(signed)
+}
+
+// signS3Request adds SigV4 authentication headers for a request without a body
+func signS3Request(req *http.Request, config S3Config, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", emptyPayloadHash)
+	if config.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", config.SessionToken)
+	}
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		lower := strings.ToLower(name)
+		if lower == "range" || strings.HasPrefix(lower, "x-amz-") {
+			headers[lower] = strings.TrimSpace(strings.Join(values, ","))
+		}
+	}
+
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalURI := req.URL.EscapedPath()
+	if canonicalURI == "" {
+		canonicalURI = "/"
+	}
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		canonicalURI,
+		canonicalQueryString(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		emptyPayloadHash,
+	}, "\n")
+
+	scope := fmt.Sprintf("%s/%s/s3/aws4_request", date, config.Region)
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		hexSHA256(canonicalRequest),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+config.SecretAccessKey), date)
+	key = hmacSHA256(key, config.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		config.AccessKeyID, scope, signedHeaders, signature))
+}
+
+// canonicalQueryString sorts and encodes query parameters as SigV4 requires
+func canonicalQueryString(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var pairs []string
+	for _, key := range keys {
+		values := query[key]
+		sort.Strings(values)
+		for _, value := range values {
+			pairs = append(pairs, escapeS3Component(key)+"="+escapeS3Component(value))
+		}
+	}
+	return strings.Join(pairs, "&")
+}
+
+// escapeS3Path URI-encodes each segment of an object path
+func escapeS3Path(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		segments[i] = escapeS3Component(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// escapeS3Component URI-encodes everything except unreserved characters, per RFC 3986
+func escapeS3Component(s string) string {
+	return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func hexSHA256(data string) string {
+	sum := sha256.Sum256([]byte(data))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,86 @@
+// ABOUTME: Detects the kind of tile source a location refers to and loads metadata from it.
+// ABOUTME: Supports .pivotal ZIPs, raw metadata YAML, extracted tile directories, and s3:// URIs.
+package metadata
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// SourceKind identifies how tile metadata is stored at a location
+type SourceKind string
+
+const (
+	SourceZip       SourceKind = "zip"
+	SourceYAML      SourceKind = "yaml"
+	SourceDirectory SourceKind = "directory"
+	SourceS3        SourceKind = "s3"
+)
+
+// zipMagic is the signature at the start of every ZIP local file header
+var zipMagic = []byte("PK\x03\x04")
+
+// DetectSource determines the kind of tile source at location
+func DetectSource(location string) (SourceKind, error) {
+	if strings.HasPrefix(location, "s3://") {
+		return SourceS3, nil
+	}
+
+	info, err := os.Stat(location)
+	if err != nil {
+		return "", fmt.Errorf("failed to access %s: %w", location, err)
+	}
+	if info.IsDir() {
+		return SourceDirectory, nil
+	}
+
+	file, err := os.Open(location)
+	if err != nil {
+		return "", fmt.Errorf("failed to open %s: %w", location, err)
+	}
+	defer file.Close()
+
+	header := make([]byte, len(zipMagic))
+	if _, err := io.ReadFull(file, header); err == nil && bytes.Equal(header, zipMagic) {
+		return SourceZip, nil
+	}
+
+	return SourceYAML, nil
+}
+
+// Load loads tile metadata from a .pivotal file, metadata YAML file, extracted tile directory, or s3:// URI
+func Load(location string) (*TileMetadata, error) {
+	kind, err := DetectSource(location)
+	if err != nil {
+		return nil, err
+	}
+
+	switch kind {
+	case SourceS3:
+		return LoadFromS3(location)
+	case SourceDirectory:
+		return LoadFromDirectory(location)
+	case SourceZip:
+		return LoadFromFile(location)
+	default:
+		return LoadFromYAMLFile(location)
+	}
+}
+
+// LoadFromDirectory loads metadata from an extracted tile directory
+func LoadFromDirectory(dir string) (*TileMetadata, error) {
+	yamlData, err := ExtractMetadataFromDirectory(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract metadata from %s: %w", dir, err)
+	}
+
+	metadata, err := ParseMetadata(yamlData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse metadata from %s: %w", dir, err)
+	}
+
+	return metadata, nil
+}
@@ -0,0 +1,100 @@
+// ABOUTME: Unit tests for detecting tile source kinds and loading metadata from each.
+// ABOUTME: Covers .pivotal ZIPs, raw metadata YAML, and extracted tile directories.
+package metadata
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testMetadataYAML = "property_blueprints:\n  - name: test_property\n    type: string\n"
+
+// writeTestSources creates a ZIP tile, a metadata YAML file, and an extracted tile directory
+func writeTestSources(t *testing.T) (zipPath, yamlPath, dirPath string) {
+	t.Helper()
+	root := t.TempDir()
+
+	zipPath = filepath.Join(root, "tile.pivotal")
+	writeTestTile(t, zipPath, 0)
+
+	yamlPath = filepath.Join(root, "metadata.yml")
+	if err := os.WriteFile(yamlPath, []byte(testMetadataYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	dirPath = filepath.Join(root, "extracted")
+	if err := os.MkdirAll(filepath.Join(dirPath, "metadata"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dirPath, "metadata", "metadata.yml"), []byte(testMetadataYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	return zipPath, yamlPath, dirPath
+}
+
+func TestDetectSource(t *testing.T) {
+	zipPath, yamlPath, dirPath := writeTestSources(t)
+
+	// A ZIP is detected by content, regardless of extension
+	renamedZip := filepath.Join(filepath.Dir(zipPath), "tile.bin")
+	data, err := os.ReadFile(zipPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(renamedZip, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		location string
+		expected SourceKind
+	}{
+		{zipPath, SourceZip},
+		{renamedZip, SourceZip},
+		{yamlPath, SourceYAML},
+		{dirPath, SourceDirectory},
+		{"s3://tiles/cf-10.2.5.pivotal", SourceS3},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.expected)+" "+filepath.Base(tt.location), func(t *testing.T) {
+			kind, err := DetectSource(tt.location)
+			if err != nil {
+				t.Fatalf("DetectSource failed: %v", err)
+			}
+			if kind != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, kind)
+			}
+		})
+	}
+}
+
+func TestDetectSource_Missing(t *testing.T) {
+	if _, err := DetectSource("/nonexistent/tile.pivotal"); err == nil {
+		t.Error("Expected error for missing location")
+	}
+}
+
+func TestLoad(t *testing.T) {
+	zipPath, yamlPath, dirPath := writeTestSources(t)
+
+	for _, location := range []string{zipPath, yamlPath, dirPath} {
+		t.Run(filepath.Base(location), func(t *testing.T) {
+			metadata, err := Load(location)
+			if err != nil {
+				t.Fatalf("Load failed: %v", err)
+			}
+			if len(metadata.PropertyBlueprints) != 1 || metadata.PropertyBlueprints[0].Name != "test_property" {
+				t.Errorf("Unexpected metadata: %+v", metadata.PropertyBlueprints)
+			}
+		})
+	}
+}
+
+func TestLoadFromDirectory_MissingMetadata(t *testing.T) {
+	if _, err := LoadFromDirectory(t.TempDir()); err == nil {
+		t.Error("Expected error for directory without metadata/metadata.yml")
+	}
+}
@@ -2,15 +2,20 @@
 // ABOUTME: Maps YAML property_blueprints to Go structs for comparison.
 package metadata
 
+import (
+	"fmt"
+	"strings"
+)
+
 // PropertyBlueprint represents a single property definition from tile metadata
 type PropertyBlueprint struct {
-	Name            string              `yaml:"name"`
-	Type            string              `yaml:"type"`
-	Configurable    bool                `yaml:"configurable"`
-	Optional        bool                `yaml:"optional"`
-	Default         interface{}         `yaml:"default,omitempty"`
-	Constraints     interface{}         `yaml:"constraints,omitempty"`
-	OptionTemplates []OptionTemplate    `yaml:"option_templates,omitempty"`
+	Name            string           `yaml:"name"`
+	Type            string           `yaml:"type"`
+	Configurable    bool             `yaml:"configurable"`
+	Optional        bool             `yaml:"optional"`
+	Default         interface{}      `yaml:"default,omitempty"`
+	Constraints     interface{}      `yaml:"constraints,omitempty"`
+	OptionTemplates []OptionTemplate `yaml:"option_templates,omitempty"`
 }
 
 // Constraints can be either:
@@ -25,7 +30,118 @@ type OptionTemplate struct {
 	PropertyBlueprints []PropertyBlueprint `yaml:"property_blueprints,omitempty"`
 }
 
+// StemcellCriteria describes the stemcell line a tile must be deployed with
+type StemcellCriteria struct {
+	OS                         string `yaml:"os"`
+	Version                    string `yaml:"version"`
+	RequiresCPI                bool   `yaml:"requires_cpi,omitempty"`
+	EnablePatchSecurityUpdates bool   `yaml:"enable_patch_security_updates,omitempty"`
+}
+
+// Release represents a BOSH release bundled in the tile
+type Release struct {
+	Name    string `yaml:"name"`
+	File    string `yaml:"file"`
+	Version string `yaml:"version"`
+}
+
+// ProductVersionRequirement represents a product the tile depends on and the version constraint it must meet
+type ProductVersionRequirement struct {
+	Name    string `yaml:"name"`
+	Version string `yaml:"version"`
+}
+
+// Errand run states as normalized by Errand.DefaultState
+const (
+	ErrandRunOn          = "on"
+	ErrandRunOff         = "off"
+	ErrandRunWhenChanged = "when-changed"
+)
+
+// Errand represents a post-deploy or pre-delete errand defined by the tile
+type Errand struct {
+	Name        string      `yaml:"name"`
+	Label       string      `yaml:"label,omitempty"`
+	Description string      `yaml:"description,omitempty"`
+	Colocated   bool        `yaml:"colocated,omitempty"`
+	RunDefault  interface{} `yaml:"run_default,omitempty"`
+}
+
+// DefaultState normalizes run_default to on, off, or when-changed; errands run by default when unset
+func (e Errand) DefaultState() string {
+	switch v := e.RunDefault.(type) {
+	case nil:
+		return ErrandRunOn
+	case bool:
+		if v {
+			return ErrandRunOn
+		}
+		return ErrandRunOff
+	case string:
+		switch strings.ToLower(v) {
+		case "true", "on", "default":
+			return ErrandRunOn
+		case "false", "off":
+			return ErrandRunOff
+		}
+		return strings.ToLower(v)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// FormType represents an Ops Manager configuration tab and the properties it exposes
+type FormType struct {
+	Name           string          `yaml:"name"`
+	Label          string          `yaml:"label"`
+	Description    string          `yaml:"description,omitempty"`
+	PropertyInputs []PropertyInput `yaml:"property_inputs,omitempty"`
+}
+
+// PropertyInput represents a labelled form field referencing a property
+type PropertyInput struct {
+	Reference              string                  `yaml:"reference"`
+	Label                  string                  `yaml:"label,omitempty"`
+	Description            string                  `yaml:"description,omitempty"`
+	PropertyInputs         []PropertyInput         `yaml:"property_inputs,omitempty"`
+	SelectorPropertyInputs []SelectorPropertyInput `yaml:"selector_property_inputs,omitempty"`
+}
+
+// SelectorPropertyInput represents a selector option and the form fields shown when it is chosen
+type SelectorPropertyInput struct {
+	Reference      string          `yaml:"reference"`
+	Label          string          `yaml:"label,omitempty"`
+	PropertyInputs []PropertyInput `yaml:"property_inputs,omitempty"`
+}
+
+// Variable represents a credential or certificate that Ops Manager/CredHub generates for the tile
+type Variable struct {
+	Name    string                 `yaml:"name"`
+	Type    string                 `yaml:"type"`
+	Options map[string]interface{} `yaml:"options,omitempty"`
+}
+
 // TileMetadata represents the top-level metadata structure
 type TileMetadata struct {
-	PropertyBlueprints []PropertyBlueprint `yaml:"property_blueprints"`
+	Name                        string                      `yaml:"name,omitempty"`
+	ProductVersion              string                      `yaml:"product_version,omitempty"`
+	PropertyBlueprints          []PropertyBlueprint         `yaml:"property_blueprints"`
+	StemcellCriteria            StemcellCriteria            `yaml:"stemcell_criteria,omitempty"`
+	AdditionalStemcellsCriteria []StemcellCriteria          `yaml:"additional_stemcells_criteria,omitempty"`
+	Releases                    []Release                   `yaml:"releases,omitempty"`
+	RequiresProductVersions     []ProductVersionRequirement `yaml:"requires_product_versions,omitempty"`
+	PostDeployErrands           []Errand                    `yaml:"post_deploy_errands,omitempty"`
+	PreDeleteErrands            []Errand                    `yaml:"pre_delete_errands,omitempty"`
+	FormTypes                   []FormType                  `yaml:"form_types,omitempty"`
+	Variables                   []Variable                  `yaml:"variables,omitempty"`
+	RuntimeConfigs              []RuntimeConfig             `yaml:"runtime_configs,omitempty"`
+}
+
+// Stemcells returns the primary and additional stemcell criteria declared by the tile
+func (m *TileMetadata) Stemcells() []StemcellCriteria {
+	var stemcells []StemcellCriteria
+	if m.StemcellCriteria.OS != "" {
+		stemcells = append(stemcells, m.StemcellCriteria)
+	}
+	return append(stemcells, m.AdditionalStemcellsCriteria...)
 }
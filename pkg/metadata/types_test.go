@@ -104,3 +104,203 @@ option_templates:
 		t.Errorf("Expected first option name 'enable', got '%s'", pb.OptionTemplates[0].Name)
 	}
 }
+
+func TestStemcellCriteriaUnmarshal(t *testing.T) {
+	yamlData := `
+property_blueprints: []
+stemcell_criteria:
+  os: ubuntu-jammy
+  version: "1.500"
+  requires_cpi: false
+  enable_patch_security_updates: true
+additional_stemcells_criteria:
+  - os: windows2019
+    version: "2019.70"
+`
+	var tm TileMetadata
+	err := yaml.Unmarshal([]byte(yamlData), &tm)
+	if err != nil {
+		t.Fatalf("Failed to unmarshal: %v", err)
+	}
+
+	if tm.StemcellCriteria.OS != "ubuntu-jammy" {
+		t.Errorf("Expected os 'ubuntu-jammy', got '%s'", tm.StemcellCriteria.OS)
+	}
+	if tm.StemcellCriteria.Version != "1.500" {
+		t.Errorf("Expected version '1.500', got '%s'", tm.StemcellCriteria.Version)
+	}
+	if !tm.StemcellCriteria.EnablePatchSecurityUpdates {
+		t.Error("Expected enable_patch_security_updates to be true")
+	}
+
+	stemcells := tm.Stemcells()
+	if len(stemcells) != 2 {
+		t.Fatalf("Expected 2 stemcells, got %d", len(stemcells))
+	}
+	if stemcells[1].OS != "windows2019" {
+		t.Errorf("Expected additional stemcell 'windows2019', got '%s'", stemcells[1].OS)
+	}
+}
+
+func TestReleasesUnmarshal(t *testing.T) {
+	yamlData := `
+releases:
+  - name: capi
+    file: capi-1.2.3.tgz
+    version: 1.2.3
+  - name: diego
+    file: diego-2.100.0.tgz
+    version: 2.100.0
+`
+	var tm TileMetadata
+	err := yaml.Unmarshal([]byte(yamlData), &tm)
+	if err != nil {
+		t.Fatalf("Failed to unmarshal: %v", err)
+	}
+
+	if len(tm.Releases) != 2 {
+		t.Fatalf("Expected 2 releases, got %d", len(tm.Releases))
+	}
+	if tm.Releases[0].Name != "capi" || tm.Releases[0].Version != "1.2.3" {
+		t.Errorf("Expected capi 1.2.3, got %s %s", tm.Releases[0].Name, tm.Releases[0].Version)
+	}
+	if tm.Releases[1].File != "diego-2.100.0.tgz" {
+		t.Errorf("Expected file 'diego-2.100.0.tgz', got '%s'", tm.Releases[1].File)
+	}
+}
+
+func TestErrandsUnmarshal(t *testing.T) {
+	yamlData := `
+post_deploy_errands:
+  - name: smoke_tests
+  - name: push-apps-manager
+    run_default: false
+  - name: rotate_cc_database_key
+    run_default: when-changed
+    colocated: true
+pre_delete_errands:
+  - name: delete-apps-manager
+    run_default: on
+`
+	var tm TileMetadata
+	err := yaml.Unmarshal([]byte(yamlData), &tm)
+	if err != nil {
+		t.Fatalf("Failed to unmarshal: %v", err)
+	}
+
+	if len(tm.PostDeployErrands) != 3 {
+		t.Fatalf("Expected 3 post-deploy errands, got %d", len(tm.PostDeployErrands))
+	}
+	if len(tm.PreDeleteErrands) != 1 {
+		t.Fatalf("Expected 1 pre-delete errand, got %d", len(tm.PreDeleteErrands))
+	}
+
+	expected := []struct {
+		errand Errand
+		state  string
+	}{
+		{tm.PostDeployErrands[0], ErrandRunOn},
+		{tm.PostDeployErrands[1], ErrandRunOff},
+		{tm.PostDeployErrands[2], ErrandRunWhenChanged},
+		{tm.PreDeleteErrands[0], ErrandRunOn},
+	}
+	for _, tt := range expected {
+		if got := tt.errand.DefaultState(); got != tt.state {
+			t.Errorf("Expected %s default state '%s', got '%s'", tt.errand.Name, tt.state, got)
+		}
+	}
+	if !tm.PostDeployErrands[2].Colocated {
+		t.Error("Expected rotate_cc_database_key to be colocated")
+	}
+}
+
+func TestFormTypesUnmarshal(t *testing.T) {
+	yamlData := `
+form_types:
+  - name: domains
+    label: Domains
+    description: Configure domains for your apps and system components
+    property_inputs:
+      - reference: .cloud_controller.system_domain
+        label: System domain
+  - name: networking
+    label: Networking
+    property_inputs:
+      - reference: .properties.routing_tls_termination
+        label: TLS termination point
+        selector_property_inputs:
+          - reference: .properties.routing_tls_termination.router
+            label: Gorouter
+            property_inputs:
+              - reference: .properties.routing_tls_termination.router.min_tls_version
+                label: Minimum TLS version
+`
+	var tm TileMetadata
+	err := yaml.Unmarshal([]byte(yamlData), &tm)
+	if err != nil {
+		t.Fatalf("Failed to unmarshal: %v", err)
+	}
+
+	if len(tm.FormTypes) != 2 {
+		t.Fatalf("Expected 2 form types, got %d", len(tm.FormTypes))
+	}
+	if tm.FormTypes[0].Label != "Domains" || tm.FormTypes[0].PropertyInputs[0].Label != "System domain" {
+		t.Errorf("Expected Domains form with System domain input, got %+v", tm.FormTypes[0])
+	}
+
+	selector := tm.FormTypes[1].PropertyInputs[0].SelectorPropertyInputs
+	if len(selector) != 1 || selector[0].Label != "Gorouter" {
+		t.Fatalf("Expected Gorouter selector option, got %+v", selector)
+	}
+	if selector[0].PropertyInputs[0].Reference != ".properties.routing_tls_termination.router.min_tls_version" {
+		t.Errorf("Unexpected nested reference: %s", selector[0].PropertyInputs[0].Reference)
+	}
+}
+
+func TestVariablesUnmarshal(t *testing.T) {
+	yamlData := `
+variables:
+  - name: diego-instance-identity-ca
+    type: certificate
+    options:
+      is_ca: true
+      common_name: instanceIdentityCA
+  - name: gorouter-cert
+    type: certificate
+    options:
+      ca: /services/intermediate_tls_ca
+      alternative_names:
+        - "*.sys.example.com"
+`
+	var tm TileMetadata
+	err := yaml.Unmarshal([]byte(yamlData), &tm)
+	if err != nil {
+		t.Fatalf("Failed to unmarshal: %v", err)
+	}
+
+	if len(tm.Variables) != 2 {
+		t.Fatalf("Expected 2 variables, got %d", len(tm.Variables))
+	}
+	if tm.Variables[0].Type != "certificate" || tm.Variables[0].Options["is_ca"] != true {
+		t.Errorf("Expected CA certificate, got %+v", tm.Variables[0])
+	}
+	names, ok := tm.Variables[1].Options["alternative_names"].([]interface{})
+	if !ok || len(names) != 1 {
+		t.Errorf("Expected one alternative name, got %v", tm.Variables[1].Options["alternative_names"])
+	}
+}
+
+func TestProductIdentityUnmarshal(t *testing.T) {
+	yamlData := `
+name: cf
+product_version: 10.2.5-build.2
+`
+	var tm TileMetadata
+	if err := yaml.Unmarshal([]byte(yamlData), &tm); err != nil {
+		t.Fatalf("Failed to unmarshal: %v", err)
+	}
+
+	if tm.Name != "cf" || tm.ProductVersion != "10.2.5-build.2" {
+		t.Errorf("Expected cf 10.2.5-build.2, got %s %s", tm.Name, tm.ProductVersion)
+	}
+}
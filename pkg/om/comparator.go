@@ -4,6 +4,7 @@ package om
 
 import (
 	"fmt"
+	"sort"
 )
 
 // ConfigChange represents a change in configuration
@@ -33,8 +34,9 @@ func CompareConfigs(oldConfig, newConfig *ProductConfig) *ConfigComparison {
 	oldProps := oldConfig.ProductProperties
 	newProps := newConfig.ProductProperties
 
-	// Find added and changed properties
-	for name, newProp := range newProps {
+	// Find added and changed properties; property paths sort by job, then path
+	for _, name := range sortedPropertyNames(newProps) {
+		newProp := newProps[name]
 		if oldProp, exists := oldProps[name]; exists {
 			// Property exists in both - check if value template changed
 			oldVal := fmt.Sprintf("%v", oldProp.Value)
@@ -60,7 +62,8 @@ func CompareConfigs(oldConfig, newConfig *ProductConfig) *ConfigComparison {
 	}
 
 	// Find removed properties
-	for name, oldProp := range oldProps {
+	for _, name := range sortedPropertyNames(oldProps) {
+		oldProp := oldProps[name]
 		if _, exists := newProps[name]; !exists {
 			result.Removed = append(result.Removed, ConfigChange{
 				PropertyName: name,
@@ -73,3 +76,13 @@ func CompareConfigs(oldConfig, newConfig *ProductConfig) *ConfigComparison {
 
 	return result
 }
+
+// sortedPropertyNames returns the property paths of props in sorted order
+func sortedPropertyNames(props map[string]PropertyValue) []string {
+	names := make([]string, 0, len(props))
+	for name := range props {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -3,7 +3,12 @@
 package om
 
 import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"sort"
 	"testing"
+	"testing/quick"
 )
 
 func TestCompareConfigs(t *testing.T) {
@@ -214,3 +219,42 @@ func TestCompareConfigs(t *testing.T) {
 		})
 	}
 }
+
+// randomConfig builds a product config with random job and product property paths
+func randomConfig(r *rand.Rand) *ProductConfig {
+	prefixes := []string{".properties.", ".cloud_controller.", ".router.", ".diego_brain."}
+	config := &ProductConfig{ProductName: "cf", ProductProperties: map[string]PropertyValue{}}
+	for i := 0; i < 80; i++ {
+		if r.Intn(3) == 0 {
+			continue
+		}
+		path := fmt.Sprintf("%sproperty_%02d", prefixes[i%len(prefixes)], i)
+		config.ProductProperties[path] = PropertyValue{Value: r.Intn(3)}
+	}
+	return config
+}
+
+func TestCompareConfigs_StableOrder(t *testing.T) {
+	stable := func(seed int64) bool {
+		r := rand.New(rand.NewSource(seed))
+		oldConfig, newConfig := randomConfig(r), randomConfig(r)
+
+		first := CompareConfigs(oldConfig, newConfig)
+		for run := 0; run < 10; run++ {
+			if !reflect.DeepEqual(first, CompareConfigs(oldConfig, newConfig)) {
+				return false
+			}
+		}
+
+		for _, changes := range [][]ConfigChange{first.Added, first.Removed, first.Changed} {
+			if !sort.SliceIsSorted(changes, func(i, j int) bool { return changes[i].PropertyName < changes[j].PropertyName }) {
+				return false
+			}
+		}
+		return true
+	}
+
+	if err := quick.Check(stable, &quick.Config{MaxCount: 25}); err != nil {
+		t.Error(err)
+	}
+}
@@ -30,7 +30,26 @@ func NewCacheManager(cacheDir, manifestFile string) *CacheManager {
 
 // Get retrieves a cache entry if it exists
 func (m *CacheManager) Get(productSlug, version string, productFileID int) *CacheEntry {
-	key := m.cacheKey(productSlug, version, productFileID)
+	return m.get(m.cacheKey(productSlug, version, productFileID))
+}
+
+// Add adds a file to the cache
+func (m *CacheManager) Add(productSlug, version string, productFileID int, filePath string, fileSize int64) error {
+	return m.add(m.cacheKey(productSlug, version, productFileID), productSlug, version, productFileID, filePath, fileSize)
+}
+
+// GetMetadata retrieves cached metadata extracted from a product file if it exists
+func (m *CacheManager) GetMetadata(productSlug, version string, productFileID int) *CacheEntry {
+	return m.get(m.metadataCacheKey(productSlug, version, productFileID))
+}
+
+// AddMetadata adds metadata extracted from a product file to the cache
+func (m *CacheManager) AddMetadata(productSlug, version string, productFileID int, filePath string, fileSize int64) error {
+	return m.add(m.metadataCacheKey(productSlug, version, productFileID), productSlug, version, productFileID, filePath, fileSize)
+}
+
+// get returns the entry for key, dropping it if its file no longer exists
+func (m *CacheManager) get(key string) *CacheEntry {
 	entry, exists := m.entries[key]
 	if !exists {
 		return nil
@@ -47,9 +66,8 @@ func (m *CacheManager) Get(productSlug, version string, productFileID int) *Cach
 	return entry
 }
 
-// Add adds a file to the cache
-func (m *CacheManager) Add(productSlug, version string, productFileID int, filePath string, fileSize int64) error {
-	key := m.cacheKey(productSlug, version, productFileID)
+// add records an entry under key and persists the manifest
+func (m *CacheManager) add(key, productSlug, version string, productFileID int, filePath string, fileSize int64) error {
 	m.entries[key] = &CacheEntry{
 		ProductSlug:   productSlug,
 		Version:       version,
@@ -96,6 +114,11 @@ func (m *CacheManager) cacheKey(productSlug, version string, productFileID int)
 	return fmt.Sprintf("%s-%s-%d", productSlug, version, productFileID)
 }
 
+// metadataCacheKey generates the cache key for metadata extracted from a product file
+func (m *CacheManager) metadataCacheKey(productSlug, version string, productFileID int) string {
+	return m.cacheKey(productSlug, version, productFileID) + "-metadata"
+}
+
 // load reads cache manifest from disk
 func (m *CacheManager) load() error {
 	data, err := os.ReadFile(m.manifestFile)
@@ -105,3 +105,41 @@ func TestCacheCleanup(t *testing.T) {
 		t.Error("Expected new file to still exist")
 	}
 }
+
+func TestCacheManager_Metadata(t *testing.T) {
+	tempDir := t.TempDir()
+	cacheDir := filepath.Join(tempDir, "cache")
+	manifestFile := filepath.Join(tempDir, "cache-manifest.json")
+
+	mgr := NewCacheManager(cacheDir, manifestFile)
+
+	tileFile := filepath.Join(cacheDir, "cf-6.0.22.pivotal")
+	metadataFile := filepath.Join(cacheDir, "metadata", "cf-6.0.22-12345.yml")
+	os.MkdirAll(filepath.Dir(metadataFile), 0755)
+	os.WriteFile(tileFile, []byte("tile"), 0644)
+	os.WriteFile(metadataFile, []byte("property_blueprints: []"), 0644)
+
+	if entry := mgr.GetMetadata("cf", "6.0.22", 12345); entry != nil {
+		t.Error("Expected nil for non-existent metadata entry")
+	}
+
+	if err := mgr.AddMetadata("cf", "6.0.22", 12345, metadataFile, 23); err != nil {
+		t.Fatalf("Failed to add metadata entry: %v", err)
+	}
+	if err := mgr.Add("cf", "6.0.22", 12345, tileFile, 4); err != nil {
+		t.Fatalf("Failed to add cache entry: %v", err)
+	}
+
+	// Metadata and full tile entries for the same product file are kept separately
+	mgr2 := NewCacheManager(cacheDir, manifestFile)
+	entry := mgr2.GetMetadata("cf", "6.0.22", 12345)
+	if entry == nil || entry.FilePath != metadataFile {
+		t.Fatalf("Expected metadata entry at %s, got %+v", metadataFile, entry)
+	}
+	if entry.Version != "6.0.22" {
+		t.Errorf("Expected version 6.0.22, got %s", entry.Version)
+	}
+	if tile := mgr2.Get("cf", "6.0.22", 12345); tile == nil || tile.FilePath != tileFile {
+		t.Errorf("Expected tile entry at %s, got %+v", tileFile, tile)
+	}
+}
@@ -119,6 +119,7 @@ func (c *Client) GetProductFiles(productSlug string, releaseID int) ([]ProductFi
 				Name:         pf.Name,
 				AWSObjectKey: pf.AWSObjectKey,
 				Size:         int64(pf.Size),
+				SHA256:       pf.SHA256,
 			})
 		}
 	}
@@ -151,6 +152,26 @@ func (c *Client) AcceptEULA(productSlug string, releaseID int) error {
 	return c.pivnetClient.EULA.Accept(productSlug, releaseID)
 }
 
+// GetDownloadURL resolves the signed, time-limited URL a product file can be fetched from
+func (c *Client) GetDownloadURL(productSlug string, releaseID, fileID int) (string, error) {
+	pf, err := c.pivnetClient.ProductFiles.GetForRelease(productSlug, releaseID, fileID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get file metadata: %w", err)
+	}
+
+	downloadLink, err := pf.DownloadLink()
+	if err != nil {
+		return "", fmt.Errorf("failed to get download link: %w", err)
+	}
+
+	signedURL, err := pivnet.NewProductFileLinkFetcher(downloadLink, c.pivnetClient).NewDownloadLink()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve download URL: %w", err)
+	}
+
+	return signedURL, nil
+}
+
 // DownloadFile downloads a product file with progress tracking
 func (c *Client) DownloadFile(productSlug string, releaseID, fileID int, file *os.File, progressWriter io.Writer) error {
 	// Create file info for the download
@@ -5,8 +5,12 @@ package pivnet
 import (
 	"fmt"
 	"io"
+	"net/http"
 	"os"
+	"path"
 	"path/filepath"
+
+	"github.com/malston/tile-diff/pkg/metadata"
 )
 
 // Downloader orchestrates file downloads
@@ -29,28 +33,166 @@ func NewDownloader(client *Client, cacheDir, manifestFile, eulaFile string, minF
 	}
 }
 
-// Download downloads a product file
-func (d *Downloader) Download(opts DownloadOptions) (string, error) {
+// Download downloads a product file, returning its local path and the product file it was downloaded from
+func (d *Downloader) Download(opts DownloadOptions) (string, *ProductFile, error) {
+	release, selectedFile, err := d.selectProductFile(opts)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// Check cache
+	cached := d.cache.Get(opts.ProductSlug, release.Version, selectedFile.ID)
+	if cached != nil {
+		d.progressf("Using cached file: %s\n", cached.FilePath)
+		return cached.FilePath, selectedFile, nil
+	}
+
+	if err := d.ensureEULA(opts, release); err != nil {
+		return "", nil, err
+	}
+
+	// Check disk space
+	hasSpace, err := d.diskManager.HasEnoughSpace(opts.CacheDir, selectedFile.Size)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to check disk space: %w", err)
+	}
+	if !hasSpace {
+		// Try cleanup
+		removed, err := d.cache.CleanupOld(7)
+		if err != nil {
+			return "", nil, fmt.Errorf("insufficient disk space and cleanup failed: %w", err)
+		}
+		d.progressf("Cleaned up %d old cached files\n", removed)
+
+		// Check again
+		hasSpace, _ = d.diskManager.HasEnoughSpace(opts.CacheDir, selectedFile.Size)
+		if !hasSpace {
+			return "", nil, fmt.Errorf("insufficient disk space even after cleanup")
+		}
+	}
+
+	// Get actual file size (ListForRelease doesn't return sizes)
+	fileSize, err := d.client.GetProductFileSize(opts.ProductSlug, release.ID, selectedFile.ID)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to get file size: %w", err)
+	}
+
+	// Download file
+	d.progressf("Downloading %s (%s)...\n", selectedFile.Name, formatBytes(fileSize))
+
+	targetPath := filepath.Join(opts.CacheDir, filepath.Base(selectedFile.AWSObjectKey))
+	if err := os.MkdirAll(opts.CacheDir, 0755); err != nil {
+		return "", nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	// Download to temp file first
+	tempPath := targetPath + ".tmp"
+	err = d.downloadFile(opts.ProductSlug, release.ID, selectedFile.ID, tempPath, fileSize)
+	if err != nil {
+		os.Remove(tempPath)
+		return "", nil, err
+	}
+
+	// Verify temp file exists before attempting rename
+	if _, err := os.Stat(tempPath); err != nil {
+		return "", nil, fmt.Errorf("downloaded temp file missing before rename: %w", err)
+	}
+
+	// Move to final location
+	if err := os.Rename(tempPath, targetPath); err != nil {
+		os.Remove(tempPath)
+		return "", nil, fmt.Errorf("failed to move downloaded file: %w", err)
+	}
+
+	// Add to cache
+	d.cache.Add(opts.ProductSlug, release.Version, selectedFile.ID, targetPath, fileSize)
+
+	d.recordEULA(opts, release)
+
+	return targetPath, selectedFile, nil
+}
+
+// FetchMetadata reads only the metadata of a product file via HTTP range requests against
+// its signed download URL, caching the extracted YAML; returns the path to the cached metadata and
+// the product file it was read from
+func (d *Downloader) FetchMetadata(opts DownloadOptions) (string, *ProductFile, error) {
+	release, selectedFile, err := d.selectProductFile(opts)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// Check cache
+	cached := d.cache.GetMetadata(opts.ProductSlug, release.Version, selectedFile.ID)
+	if cached != nil {
+		d.progressf("Using cached metadata: %s\n", cached.FilePath)
+		return cached.FilePath, selectedFile, nil
+	}
+
+	if err := d.ensureEULA(opts, release); err != nil {
+		return "", nil, err
+	}
+
+	downloadURL, err := d.client.GetDownloadURL(opts.ProductSlug, release.ID, selectedFile.ID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	d.progressf("Reading metadata from %s...\n", selectedFile.Name)
+
+	targetPath := filepath.Join(opts.CacheDir, "metadata",
+		fmt.Sprintf("%s-%s-%d.yml", opts.ProductSlug, release.Version, selectedFile.ID))
+	size, err := saveRemoteMetadata(http.DefaultClient, downloadURL, targetPath)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// Add to cache
+	d.cache.AddMetadata(opts.ProductSlug, release.Version, selectedFile.ID, targetPath, size)
+
+	d.recordEULA(opts, release)
+
+	return targetPath, selectedFile, nil
+}
+
+// saveRemoteMetadata extracts metadata from the tile at url and writes it to targetPath
+func saveRemoteMetadata(client *http.Client, url, targetPath string) (int64, error) {
+	data, err := metadata.ExtractMetadataFromURL(client, url)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read remote metadata: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(targetPath), 0755); err != nil {
+		return 0, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	if err := os.WriteFile(targetPath, data, 0644); err != nil {
+		return 0, fmt.Errorf("failed to write metadata: %w", err)
+	}
+
+	return int64(len(data)), nil
+}
+
+// selectProductFile resolves the release version and product file to fetch
+func (d *Downloader) selectProductFile(opts DownloadOptions) (*Release, *ProductFile, error) {
 	// Resolve version
 	releases, err := d.client.GetReleases(opts.ProductSlug)
 	if err != nil {
-		return "", err
+		return nil, nil, err
 	}
 
 	resolver := NewResolver(releases, opts.NonInteractive)
 	result, err := resolver.Resolve(opts.Version)
 	if err != nil {
-		return "", err
+		return nil, nil, err
 	}
 
 	// Handle multiple matches
 	if result.Selected == nil {
 		if opts.NonInteractive {
-			return "", fmt.Errorf("multiple releases match - use exact version in non-interactive mode")
+			return nil, nil, fmt.Errorf("multiple releases match - use exact version in non-interactive mode")
 		}
 		selected, err := PromptForRelease(result.Matches)
 		if err != nil {
-			return "", err
+			return nil, nil, err
 		}
 		result.Selected = selected
 	}
@@ -60,11 +202,11 @@ func (d *Downloader) Download(opts DownloadOptions) (string, error) {
 	// Get product files
 	productFiles, err := d.client.GetProductFiles(opts.ProductSlug, release.ID)
 	if err != nil {
-		return "", err
+		return nil, nil, err
 	}
 
 	if len(productFiles) == 0 {
-		return "", fmt.Errorf("no product files found for release %s", opts.Version)
+		return nil, nil, fmt.Errorf("no product files found for release %s", opts.Version)
 	}
 
 	// Select product file
@@ -78,146 +220,120 @@ func (d *Downloader) Download(opts DownloadOptions) (string, error) {
 			}
 		}
 		if selectedFile == nil {
-			return "", fmt.Errorf("product file '%s' not found", opts.ProductFile)
+			return nil, nil, fmt.Errorf("product file '%s' not found", opts.ProductFile)
 		}
+	} else if defaultFile := matchDefaultFile(productFiles, opts.DefaultFile); defaultFile != nil {
+		selectedFile = defaultFile
 	} else {
 		if opts.NonInteractive {
 			if len(productFiles) > 1 {
-				return "", fmt.Errorf("multiple product files found - specify --product-file in non-interactive mode")
+				return nil, nil, fmt.Errorf("multiple product files found - specify --product-file in non-interactive mode")
 			}
 			selectedFile = &productFiles[0]
 		} else {
 			// Interactive selection
 			selected, err := PromptForProductFile(productFiles)
 			if err != nil {
-				return "", err
+				return nil, nil, err
 			}
 			selectedFile = selected
 		}
 	}
 
-	// Check cache
-	cached := d.cache.Get(opts.ProductSlug, release.Version, selectedFile.ID)
-	if cached != nil {
-		fmt.Printf("Using cached file: %s\n", cached.FilePath)
-		return cached.FilePath, nil
-	}
-
-	// Check EULA (per-release acceptance required)
-	if !d.eula.IsAcceptedForRelease(opts.ProductSlug, release.Version) {
-		eulaURL := fmt.Sprintf("https://network.tanzu.vmware.com/products/%s/releases/%d", opts.ProductSlug, release.ID)
-
-		if opts.NonInteractive && !opts.AcceptEULA {
-			return "", fmt.Errorf("EULA not accepted for %s.\n\nPlease accept the EULA at:\n%s\n\nThen run this command again, or use --accept-eula to acknowledge you've accepted it.", opts.ProductSlug, eulaURL)
-		}
-
-		if !opts.AcceptEULA {
-			// Interactive EULA prompt
-			accepted, err := PromptForEULA(opts.ProductSlug, release.Version, eulaURL)
-			if err != nil {
-				return "", err
-			}
-			if !accepted {
-				return "", fmt.Errorf("EULA not accepted")
-			}
-		}
+	return release, selectedFile, nil
+}
 
-		// Try to accept EULA via API
-		// Note: This only works for VMware/Broadcom employees
-		err := d.client.AcceptEULA(opts.ProductSlug, release.ID)
-		if err != nil {
-			// API acceptance failed - handle based on interactive mode
-			if opts.NonInteractive {
-				// Non-interactive: Assume user has manually accepted EULA via web
-				// Mark as accepted locally and proceed (download will fail if not actually accepted)
-				d.eula.Accept(opts.ProductSlug, release.Version, eulaURL)
-				fmt.Printf("Note: API EULA acceptance unavailable. Proceeding with download...\n")
-			} else {
-				// Interactive: Prompt user to accept manually via web
-				fmt.Printf("\n⚠️  EULA must be accepted manually\n")
-				fmt.Printf("API EULA acceptance is only available for Broadcom/VMware employees.\n")
-				fmt.Printf("\nPlease:\n")
-				fmt.Printf("1. Open this URL in your browser: %s\n", eulaURL)
-				fmt.Printf("2. Accept the EULA\n")
-				fmt.Printf("3. Press Enter here to continue...\n\n")
-				fmt.Scanln()
-
-				// Don't mark as accepted yet - let the download verify it
-				// If the download succeeds, we'll mark it then
-				fmt.Printf("Proceeding with download (EULA acceptance will be verified)...\n")
-			}
-		} else {
-			// API acceptance succeeded (Broadcom/VMware employee)
-			d.eula.Accept(opts.ProductSlug, release.Version, eulaURL)
-			fmt.Printf("EULA accepted via API for %s\n", opts.ProductSlug)
-		}
+// matchDefaultFile returns the one product file whose name matches the default name or glob, if exactly one does
+func matchDefaultFile(files []ProductFile, pattern string) *ProductFile {
+	if pattern == "" {
+		return nil
 	}
 
-	// Check disk space
-	hasSpace, err := d.diskManager.HasEnoughSpace(opts.CacheDir, selectedFile.Size)
-	if err != nil {
-		return "", fmt.Errorf("failed to check disk space: %w", err)
-	}
-	if !hasSpace {
-		// Try cleanup
-		removed, err := d.cache.CleanupOld(7)
-		if err != nil {
-			return "", fmt.Errorf("insufficient disk space and cleanup failed: %w", err)
+	var match *ProductFile
+	for i := range files {
+		if ok, err := path.Match(pattern, files[i].Name); err != nil || !ok {
+			continue
 		}
-		fmt.Printf("Cleaned up %d old cached files\n", removed)
-
-		// Check again
-		hasSpace, _ = d.diskManager.HasEnoughSpace(opts.CacheDir, selectedFile.Size)
-		if !hasSpace {
-			return "", fmt.Errorf("insufficient disk space even after cleanup")
+		if match != nil {
+			return nil
 		}
+		match = &files[i]
 	}
+	return match
+}
 
-	// Get actual file size (ListForRelease doesn't return sizes)
-	fileSize, err := d.client.GetProductFileSize(opts.ProductSlug, release.ID, selectedFile.ID)
-	if err != nil {
-		return "", fmt.Errorf("failed to get file size: %w", err)
+// ensureEULA checks EULA acceptance for the release, prompting or accepting via API as needed
+func (d *Downloader) ensureEULA(opts DownloadOptions, release *Release) error {
+	// Check EULA (per-release acceptance required)
+	if d.eula.IsAcceptedForRelease(opts.ProductSlug, release.Version) {
+		return nil
 	}
 
-	// Download file
-	fmt.Printf("Downloading %s (%s)...\n", selectedFile.Name, formatBytes(fileSize))
-
-	targetPath := filepath.Join(opts.CacheDir, filepath.Base(selectedFile.AWSObjectKey))
-	if err := os.MkdirAll(opts.CacheDir, 0755); err != nil {
-		return "", fmt.Errorf("failed to create cache directory: %w", err)
-	}
+	eulaURL := fmt.Sprintf("https://network.tanzu.vmware.com/products/%s/releases/%d", opts.ProductSlug, release.ID)
 
-	// Download to temp file first
-	tempPath := targetPath + ".tmp"
-	err = d.downloadFile(opts.ProductSlug, release.ID, selectedFile.ID, tempPath, fileSize)
-	if err != nil {
-		os.Remove(tempPath)
-		return "", err
+	if opts.NonInteractive && !opts.AcceptEULA {
+		return fmt.Errorf("EULA not accepted for %s.\n\nPlease accept the EULA at:\n%s\n\nThen run this command again, or use --accept-eula to acknowledge you've accepted it.", opts.ProductSlug, eulaURL)
 	}
 
-	// Verify temp file exists before attempting rename
-	if _, err := os.Stat(tempPath); err != nil {
-		return "", fmt.Errorf("downloaded temp file missing before rename: %w", err)
+	if !opts.AcceptEULA {
+		// Interactive EULA prompt
+		accepted, err := PromptForEULA(opts.ProductSlug, release.Version, eulaURL)
+		if err != nil {
+			return err
+		}
+		if !accepted {
+			return fmt.Errorf("EULA not accepted")
+		}
 	}
 
-	// Move to final location
-	if err := os.Rename(tempPath, targetPath); err != nil {
-		os.Remove(tempPath)
-		return "", fmt.Errorf("failed to move downloaded file: %w", err)
+	// Try to accept EULA via API
+	// Note: This only works for VMware/Broadcom employees
+	err := d.client.AcceptEULA(opts.ProductSlug, release.ID)
+	if err != nil {
+		// API acceptance failed - handle based on interactive mode
+		if opts.NonInteractive {
+			// Non-interactive: Assume user has manually accepted EULA via web
+			// Mark as accepted locally and proceed (download will fail if not actually accepted)
+			d.eula.Accept(opts.ProductSlug, release.Version, eulaURL)
+			d.progressf("Note: API EULA acceptance unavailable. Proceeding with download...\n")
+		} else {
+			// Interactive: Prompt user to accept manually via web, on stderr so stdout stays report-only
+			fmt.Fprintf(os.Stderr, "\n⚠️  EULA must be accepted manually\n")
+			fmt.Fprintf(os.Stderr, "API EULA acceptance is only available for Broadcom/VMware employees.\n")
+			fmt.Fprintf(os.Stderr, "\nPlease:\n")
+			fmt.Fprintf(os.Stderr, "1. Open this URL in your browser: %s\n", eulaURL)
+			fmt.Fprintf(os.Stderr, "2. Accept the EULA\n")
+			fmt.Fprintf(os.Stderr, "3. Press Enter here to continue...\n\n")
+			fmt.Scanln()
+
+			// Don't mark as accepted yet - let the download verify it
+			// If the download succeeds, we'll mark it then
+			fmt.Fprintf(os.Stderr, "Proceeding with download (EULA acceptance will be verified)...\n")
+		}
+	} else {
+		// API acceptance succeeded (Broadcom/VMware employee)
+		d.eula.Accept(opts.ProductSlug, release.Version, eulaURL)
+		d.progressf("EULA accepted via API for %s\n", opts.ProductSlug)
 	}
 
-	// Add to cache
-	d.cache.Add(opts.ProductSlug, release.Version, selectedFile.ID, targetPath, fileSize)
+	return nil
+}
 
-	// Mark EULA as accepted now that download succeeded
-	// (If it wasn't already marked via API acceptance)
+// recordEULA marks the EULA as accepted once a download has succeeded
+// (if it wasn't already marked via API acceptance)
+func (d *Downloader) recordEULA(opts DownloadOptions, release *Release) {
 	if !d.eula.IsAcceptedForRelease(opts.ProductSlug, release.Version) {
 		eulaURL := fmt.Sprintf("https://network.tanzu.vmware.com/products/%s/releases/%d", opts.ProductSlug, release.ID)
 		d.eula.Accept(opts.ProductSlug, release.Version, eulaURL)
-		fmt.Printf("EULA acceptance recorded for %s %s\n", opts.ProductSlug, release.Version)
+		d.progressf("EULA acceptance recorded for %s %s\n", opts.ProductSlug, release.Version)
 	}
+}
 
-	return targetPath, nil
+// progressf prints a progress message to stdout unless the downloader is quiet
+func (d *Downloader) progressf(format string, args ...interface{}) {
+	if !d.quiet {
+		fmt.Printf(format, args...)
+	}
 }
 
 // downloadFile downloads a file with progress bar
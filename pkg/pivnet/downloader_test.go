@@ -3,8 +3,15 @@
 package pivnet
 
 import (
+	"archive/zip"
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestDownloader(t *testing.T) {
@@ -27,3 +34,102 @@ func TestDownloader(t *testing.T) {
 		t.Fatal("Expected non-nil downloader")
 	}
 }
+
+func TestSaveRemoteMetadata(t *testing.T) {
+	// Build a fixture tile with a large release so only a small part should be fetched
+	buf := new(bytes.Buffer)
+	w := zip.NewWriter(buf)
+	f, _ := w.Create("metadata/metadata.yml")
+	f.Write([]byte("property_blueprints:\n  - name: remote_property\n    type: string\n"))
+	release, _ := w.CreateHeader(&zip.FileHeader{Name: "releases/big.tgz", Method: zip.Store})
+	release.Write(make([]byte, 8<<20))
+	w.Close()
+	tile := buf.Bytes()
+
+	var rangeRequests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Range") != "" {
+			rangeRequests++
+		}
+		http.ServeContent(w, r, "tile.pivotal", time.Time{}, bytes.NewReader(tile))
+	}))
+	defer server.Close()
+
+	targetPath := filepath.Join(t.TempDir(), "metadata", "cf-10.2.5-1.yml")
+	size, err := saveRemoteMetadata(server.Client(), server.URL, targetPath)
+	if err != nil {
+		t.Fatalf("saveRemoteMetadata failed: %v", err)
+	}
+
+	data, err := os.ReadFile(targetPath)
+	if err != nil {
+		t.Fatalf("Expected metadata written to %s: %v", targetPath, err)
+	}
+	if int64(len(data)) != size || !strings.Contains(string(data), "remote_property") {
+		t.Errorf("Unexpected metadata content (%d bytes): %s", size, data)
+	}
+	if rangeRequests == 0 {
+		t.Error("Expected metadata to be fetched with range requests")
+	}
+}
+
+func TestMatchDefaultFile(t *testing.T) {
+	files := []ProductFile{
+		{ID: 1, Name: "Small Footprint TAS for VMs"},
+		{ID: 2, Name: "TAS for VMs"},
+		{ID: 3, Name: "TAS for VMs Windows"},
+	}
+
+	tests := []struct {
+		pattern  string
+		expected int // ID of the selected file, 0 for none
+	}{
+		{"TAS for VMs", 2},
+		{"Small Footprint*", 1},
+		{"TAS for VMs*", 0}, // ambiguous
+		{"Isolation Segment", 0},
+		{"", 0},
+	}
+
+	for _, tt := range tests {
+		got := matchDefaultFile(files, tt.pattern)
+		switch {
+		case tt.expected == 0 && got != nil:
+			t.Errorf("matchDefaultFile(%q) = %s, expected no match", tt.pattern, got.Name)
+		case tt.expected != 0 && (got == nil || got.ID != tt.expected):
+			t.Errorf("matchDefaultFile(%q) = %v, expected file %d", tt.pattern, got, tt.expected)
+		}
+	}
+}
+
+func TestDownloader_QuietKeepsStdoutClean(t *testing.T) {
+	client, err := NewClient("test-token")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	tempDir := t.TempDir()
+	downloader := NewDownloader(client, filepath.Join(tempDir, "cache"), filepath.Join(tempDir, "manifest.json"),
+		filepath.Join(tempDir, "eulas.json"), 20, true)
+
+	stdout := os.Stdout
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.Stdout = w
+	downloader.progressf("Reading metadata from %s...\n", "srt-10.2.5.pivotal")
+	downloader.recordEULA(DownloadOptions{ProductSlug: "cf"}, &Release{ID: 1, Version: "10.2.5"})
+	w.Close()
+	os.Stdout = stdout
+
+	var captured bytes.Buffer
+	if _, err := captured.ReadFrom(r); err != nil {
+		t.Fatal(err)
+	}
+	if captured.Len() != 0 {
+		t.Errorf("Expected no stdout in quiet mode, got %q", captured.String())
+	}
+	if !downloader.eula.IsAcceptedForRelease("cf", "10.2.5") {
+		t.Error("Expected EULA acceptance to be recorded")
+	}
+}
@@ -1,10 +1,13 @@
-// ABOUTME: Version resolution logic for fuzzy and exact matching.
-// ABOUTME: Handles disambiguation and interactive selection when needed.
+// ABOUTME: Version resolution logic for exact versions, semver ranges, and latest selectors.
+// ABOUTME: Orders matches newest first for disambiguation and interactive selection.
 package pivnet
 
 import (
 	"fmt"
+	"sort"
 	"strings"
+
+	"github.com/malston/tile-diff/pkg/version"
 )
 
 // ResolveResult holds the result of version resolution
@@ -27,23 +30,38 @@ func NewResolver(releases []Release, nonInteractive bool) *Resolver {
 	}
 }
 
-// Resolve resolves a version string to a specific release
-func (r *Resolver) Resolve(version string) (*ResolveResult, error) {
-	matches := []Release{}
+// Version selector keywords that resolve to a single release
+const (
+	SelectLatest    = "latest"
+	SelectLatestLTS = "latest-lts"
+)
 
-	// Find all matching releases
+// Resolve resolves a version selector to a release. A selector is an exact Pivnet version, a version
+// range such as "6.0.22", "~10.2" or ">=6.0.20 <6.1", or "latest" or "latest-lts" optionally followed by
+// a range ("latest 10.2"). Keywords pick the newest match, skipping pre-releases unless the range names
+// one; other matches are returned newest first.
+func (r *Resolver) Resolve(selector string) (*ResolveResult, error) {
+	// An exact version string always names its release
 	for _, rel := range r.releases {
-		if matchesVersion(rel.Version, version) {
-			matches = append(matches, rel)
+		if rel.Version == selector {
+			return &ResolveResult{Selected: &rel, Matches: []Release{rel}}, nil
 		}
 	}
 
+	keyword, expr := splitSelector(selector)
+	versionRange, err := version.ParseRange(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid version %q: %w", selector, err)
+	}
+
+	// "latest" skips unreleased builds such as 10.3.0-build.12 unless the range asks for one
+	skipPrereleases := keyword != "" && !versionRange.NamesPrerelease()
+	matches := r.matching(versionRange, keyword == SelectLatestLTS, skipPrereleases)
 	if len(matches) == 0 {
-		return nil, fmt.Errorf("no releases found matching version %s", version)
+		return nil, fmt.Errorf("no releases found matching version %s", selector)
 	}
 
-	if len(matches) == 1 {
-		// Single match - resolved
+	if keyword != "" || len(matches) == 1 {
 		return &ResolveResult{
 			Selected: &matches[0],
 			Matches:  matches,
@@ -52,7 +70,12 @@ func (r *Resolver) Resolve(version string) (*ResolveResult, error) {
 
 	// Multiple matches
 	if r.nonInteractive {
-		return nil, fmt.Errorf("multiple releases match version %s (use exact version string in non-interactive mode)", version)
+		versions := make([]string, len(matches))
+		for i, match := range matches {
+			versions[i] = match.Version
+		}
+		return nil, fmt.Errorf("multiple releases match version %s: %s (use an exact version or \"latest %s\" in non-interactive mode)",
+			selector, strings.Join(versions, ", "), selector)
 	}
 
 	// Interactive mode - return matches for user selection
@@ -62,13 +85,41 @@ func (r *Resolver) Resolve(version string) (*ResolveResult, error) {
 	}, nil
 }
 
-// matchesVersion checks if a full version string matches a search string
-func matchesVersion(fullVersion, searchString string) bool {
-	// Exact match
-	if fullVersion == searchString {
-		return true
+// splitSelector separates a leading latest or latest-lts keyword from the range that follows it
+func splitSelector(selector string) (string, string) {
+	fields := strings.Fields(selector)
+	if len(fields) > 0 {
+		if keyword := strings.ToLower(fields[0]); keyword == SelectLatest || keyword == SelectLatestLTS {
+			return keyword, strings.Join(fields[1:], " ")
+		}
 	}
+	return "", selector
+}
 
-	// Prefix match (fuzzy)
-	return strings.HasPrefix(fullVersion, searchString)
+// matching returns the releases in a range, newest first, optionally only long-term support or
+// general availability releases
+func (r *Resolver) matching(versionRange version.Range, ltsOnly, gaOnly bool) []Release {
+	type candidate struct {
+		release Release
+		version version.Version
+	}
+
+	var candidates []candidate
+	for _, rel := range r.releases {
+		v, err := version.Parse(rel.Version)
+		if err != nil || !versionRange.Contains(v) || (ltsOnly && !v.IsLTS()) || (gaOnly && v.Prerelease != "") {
+			continue
+		}
+		candidates = append(candidates, candidate{rel, v})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].version.Compare(candidates[j].version) > 0
+	})
+
+	matches := make([]Release, len(candidates))
+	for i, c := range candidates {
+		matches[i] = c.release
+	}
+	return matches
 }
@@ -3,6 +3,7 @@
 package pivnet
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -99,7 +100,7 @@ func TestResolveVersion(t *testing.T) {
 	}
 }
 
-func TestMatchesVersion(t *testing.T) {
+func TestResolveVersion_Matching(t *testing.T) {
 	tests := []struct {
 		name         string
 		fullVersion  string
@@ -130,15 +131,96 @@ func TestMatchesVersion(t *testing.T) {
 			searchString: "10.2",
 			wantMatch:    false,
 		},
+		{
+			name:         "patch is not a prefix",
+			fullVersion:  "6.0.22+LTS-T",
+			searchString: "6.0.2",
+			wantMatch:    false,
+		},
+		{
+			name:         "range match",
+			fullVersion:  "6.0.22+LTS-T",
+			searchString: ">=6.0.20 <6.1",
+			wantMatch:    true,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := matchesVersion(tt.fullVersion, tt.searchString)
+			resolver := NewResolver([]Release{{ID: 1, Version: tt.fullVersion}}, true)
+			result, err := resolver.Resolve(tt.searchString)
+			got := err == nil && result.Selected != nil
 			if got != tt.wantMatch {
-				t.Errorf("matchesVersion(%s, %s) = %v, want %v",
-					tt.fullVersion, tt.searchString, got, tt.wantMatch)
+				t.Errorf("Resolve(%s) against %s matched = %v, want %v",
+					tt.searchString, tt.fullVersion, got, tt.wantMatch)
 			}
 		})
 	}
 }
+
+func TestResolveVersion_Selectors(t *testing.T) {
+	// Pivnet lists releases in no guaranteed order
+	releases := []Release{
+		{ID: 1, Version: "6.0.2+LTS-T"},
+		{ID: 2, Version: "6.0.22+LTS-T"},
+		{ID: 3, Version: "10.2.4+LTS-T"},
+		{ID: 4, Version: "6.0.20+LTS-T"},
+		{ID: 5, Version: "10.3.0-build.12"},
+		{ID: 6, Version: "10.2.5+LTS-T"},
+		{ID: 7, Version: "10.3.0-build.2"},
+	}
+
+	tests := []struct {
+		selector    string
+		wantVersion string   // Selected release, if one is chosen
+		wantMatches []string // Matches offered, newest first
+	}{
+		{"6.0.2", "6.0.2+LTS-T", nil},
+		{"latest", "10.2.5+LTS-T", nil},
+		{"latest 10.3.0-build.2", "10.3.0-build.2", nil},
+		{"latest >=10.3.0-build.1", "10.3.0-build.12", nil},
+		{"latest-lts", "10.2.5+LTS-T", nil},
+		{"latest 6.0", "6.0.22+LTS-T", nil},
+		{"LATEST-LTS ~10.2", "10.2.5+LTS-T", nil},
+		{"~10.2", "", []string{"10.2.5+LTS-T", "10.2.4+LTS-T"}},
+		{">=6.0.20 <6.1", "", []string{"6.0.22+LTS-T", "6.0.20+LTS-T"}},
+		{"6.0", "", []string{"6.0.22+LTS-T", "6.0.20+LTS-T", "6.0.2+LTS-T"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			result, err := NewResolver(releases, false).Resolve(tt.selector)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if tt.wantVersion != "" {
+				if result.Selected == nil || result.Selected.Version != tt.wantVersion {
+					t.Errorf("Expected %s to be selected, got %+v", tt.wantVersion, result.Selected)
+				}
+				return
+			}
+
+			var got []string
+			for _, match := range result.Matches {
+				got = append(got, match.Version)
+			}
+			if result.Selected != nil || strings.Join(got, " ") != strings.Join(tt.wantMatches, " ") {
+				t.Errorf("Expected matches %v, got %v (selected %+v)", tt.wantMatches, got, result.Selected)
+			}
+		})
+	}
+}
+
+func TestResolveVersion_NonInteractiveListsNewestFirst(t *testing.T) {
+	releases := []Release{{ID: 1, Version: "6.0.20+LTS-T"}, {ID: 2, Version: "6.0.22+LTS-T"}}
+
+	_, err := NewResolver(releases, true).Resolve("6.0")
+	if err == nil || !strings.Contains(err.Error(), "6.0.22+LTS-T, 6.0.20+LTS-T") || !strings.Contains(err.Error(), `"latest 6.0"`) {
+		t.Errorf("Expected ambiguity error listing newest first, got %v", err)
+	}
+
+	if _, err := NewResolver(releases, true).Resolve(">=banana"); err == nil || !strings.Contains(err.Error(), "invalid version") {
+		t.Errorf("Expected invalid selector error, got %v", err)
+	}
+}
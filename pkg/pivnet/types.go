@@ -25,6 +25,7 @@ type DownloadOptions struct {
 	ProductSlug    string
 	Version        string
 	ProductFile    string // Optional
+	DefaultFile    string // Product file name or glob to pick when ProductFile is unset, e.g. from the product registry
 	AcceptEULA     bool
 	NonInteractive bool
 	CacheDir       string
@@ -73,6 +74,7 @@ type ProductFile struct {
 	Name         string
 	AWSObjectKey string
 	Size         int64
+	SHA256       string
 }
 
 // CacheEntry represents a cached download
@@ -0,0 +1,122 @@
+// ABOUTME: Persistent on-disk cache of release notes pages.
+// ABOUTME: Stores page bodies with their ETag and Last-Modified validators for revalidation and offline use.
+package releasenotes
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// DefaultCacheTTL is how long a cached page is used before it is revalidated
+const DefaultCacheTTL = 24 * time.Hour
+
+// CachedPage is a release notes page stored on disk
+type CachedPage struct {
+	URL          string    `json:"url"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"last_modified,omitempty"`
+	FetchedAt    time.Time `json:"fetched_at"`
+	Body         string    `json:"-"`
+}
+
+// PageCache stores release notes pages in a directory, one body and one metadata file per URL
+type PageCache struct {
+	dir string
+	ttl time.Duration
+	mu  sync.Mutex
+}
+
+// NewPageCache creates a cache in dir; pages older than ttl are revalidated, and a ttl of zero always revalidates
+func NewPageCache(dir string, ttl time.Duration) *PageCache {
+	return &PageCache{dir: dir, ttl: ttl}
+}
+
+// DefaultCacheDir returns ~/.tile-diff/release-notes
+func DefaultCacheDir() string {
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".tile-diff", "release-notes")
+}
+
+// Dir returns the directory pages are stored in
+func (c *PageCache) Dir() string {
+	return c.dir
+}
+
+// Get returns the cached page for url, if any
+func (c *PageCache) Get(url string) (*CachedPage, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	base := c.path(url)
+	data, err := os.ReadFile(base + ".json")
+	if err != nil {
+		return nil, false
+	}
+	var page CachedPage
+	if err := json.Unmarshal(data, &page); err != nil || page.URL != url {
+		return nil, false
+	}
+
+	body, err := os.ReadFile(base + ".html")
+	if err != nil {
+		return nil, false
+	}
+	page.Body = string(body)
+	return &page, true
+}
+
+// Put stores a page, writing each file atomically so concurrent readers never see a partial page
+func (c *PageCache) Put(page *CachedPage) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := os.MkdirAll(c.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create release notes cache directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(page, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	base := c.path(page.URL)
+	if err := writeFileAtomic(base+".html", []byte(page.Body)); err != nil {
+		return err
+	}
+	return writeFileAtomic(base+".json", data)
+}
+
+// Fresh reports whether a cached page is within the cache's TTL
+func (c *PageCache) Fresh(page *CachedPage) bool {
+	return time.Since(page.FetchedAt) < c.ttl
+}
+
+// path returns the cache file path for url, without extension
+func (c *PageCache) path(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:]))
+}
+
+// writeFileAtomic writes data to a temporary file in the same directory and renames it into place
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to write release notes cache: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write release notes cache: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write release notes cache: %w", err)
+	}
+	return os.Rename(tmp.Name(), path)
+}
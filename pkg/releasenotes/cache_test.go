@@ -0,0 +1,80 @@
+// ABOUTME: Unit tests for the on-disk release notes page cache.
+// ABOUTME: Covers storing and reading pages, TTL freshness, and concurrent access.
+package releasenotes
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestPageCache_PutGet(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "release-notes")
+	cache := NewPageCache(dir, time.Hour)
+
+	if _, ok := cache.Get("https://docs.example.com/10-2"); ok {
+		t.Fatal("Expected empty cache to miss")
+	}
+
+	page := &CachedPage{
+		URL:          "https://docs.example.com/10-2",
+		ETag:         `"abc"`,
+		LastModified: "Mon, 02 Mar 2026 10:00:00 GMT",
+		FetchedAt:    time.Now().UTC(),
+		Body:         "<html>notes</html>",
+	}
+	if err := cache.Put(page); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+
+	// A new cache over the same directory sees the page, as a later run would
+	got, ok := NewPageCache(dir, time.Hour).Get(page.URL)
+	if !ok {
+		t.Fatal("Expected cached page")
+	}
+	if got.Body != page.Body || got.ETag != page.ETag || got.LastModified != page.LastModified {
+		t.Errorf("Expected %+v, got %+v", page, got)
+	}
+
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 2 {
+		t.Errorf("Expected a body and a metadata file, got %d entries", len(entries))
+	}
+}
+
+func TestPageCache_Fresh(t *testing.T) {
+	cache := NewPageCache(t.TempDir(), time.Hour)
+
+	if !cache.Fresh(&CachedPage{FetchedAt: time.Now().Add(-time.Minute)}) {
+		t.Error("Expected page fetched a minute ago to be fresh")
+	}
+	if cache.Fresh(&CachedPage{FetchedAt: time.Now().Add(-2 * time.Hour)}) {
+		t.Error("Expected page fetched two hours ago to be stale")
+	}
+	if NewPageCache(t.TempDir(), 0).Fresh(&CachedPage{FetchedAt: time.Now()}) {
+		t.Error("Expected a zero TTL to always revalidate")
+	}
+}
+
+func TestPageCache_Concurrent(t *testing.T) {
+	cache := NewPageCache(t.TempDir(), time.Hour)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			url := fmt.Sprintf("https://docs.example.com/%d", i%4)
+			if err := cache.Put(&CachedPage{URL: url, FetchedAt: time.Now(), Body: url}); err != nil {
+				t.Errorf("Put failed: %v", err)
+			}
+			if page, ok := cache.Get(url); ok && page.Body != url {
+				t.Errorf("Expected body %q, got %q", url, page.Body)
+			}
+		}(i)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,146 @@
+// ABOUTME: Component version tables from release notes and their comparison across releases.
+// ABOUTME: Diffs component versions between releases and reconciles them with the tile's BOSH releases.
+package releasenotes
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/malston/tile-diff/pkg/metadata"
+	"github.com/malston/tile-diff/pkg/version"
+)
+
+// Component is a row of a release's component version table, such as gorouter 0.300.0
+type Component struct {
+	Name    string
+	Version string
+}
+
+// ComponentChange is a component whose version differs between two releases; an empty
+// OldVersion means the component was added and an empty NewVersion that it was removed
+type ComponentChange struct {
+	Name       string
+	OldVersion string
+	NewVersion string
+}
+
+// ComponentMismatch is a component whose release notes version differs from the BOSH release bundled in the tile
+type ComponentMismatch struct {
+	Name         string // Component name in the release notes
+	Release      string // BOSH release name in the tile metadata
+	NotesVersion string
+	TileVersion  string
+}
+
+// LatestComponents returns the version and component table of the newest release that lists components
+func (n *ReleaseNotes) LatestComponents() (string, []Component) {
+	for i := len(n.Releases) - 1; i >= 0; i-- {
+		if len(n.Releases[i].Components) > 0 {
+			return n.Releases[i].Version, n.Releases[i].Components
+		}
+	}
+	return "", nil
+}
+
+// ComponentsFor returns the component table of the release matching v, or nil if none lists components
+func (n *ReleaseNotes) ComponentsFor(v string) []Component {
+	for _, release := range n.Releases {
+		if cmp, err := version.Compare(release.Version, v); err == nil && cmp == 0 && len(release.Components) > 0 {
+			return release.Components
+		}
+	}
+	return nil
+}
+
+// ComponentChanges diffs the baseline release's components against the newest release listing components;
+// nothing is reported when the baseline's notes were not available
+func (n *ReleaseNotes) ComponentChanges() []ComponentChange {
+	if n.Baseline == nil || len(n.Baseline.Components) == 0 {
+		return nil
+	}
+	_, latest := n.LatestComponents()
+	if len(latest) == 0 {
+		return nil
+	}
+	return DiffComponents(n.Baseline.Components, latest)
+}
+
+// DiffComponents identifies components added, removed, or at a different version, sorted by name
+func DiffComponents(oldComponents, newComponents []Component) []ComponentChange {
+	oldVersions := buildComponentMap(oldComponents)
+	newVersions := buildComponentMap(newComponents)
+
+	var changes []ComponentChange
+	for key, newComponent := range newVersions {
+		oldComponent, exists := oldVersions[key]
+		if !exists {
+			changes = append(changes, ComponentChange{Name: newComponent.Name, NewVersion: newComponent.Version})
+		} else if !sameVersion(oldComponent.Version, newComponent.Version) {
+			changes = append(changes, ComponentChange{Name: newComponent.Name, OldVersion: oldComponent.Version, NewVersion: newComponent.Version})
+		}
+	}
+	for key, oldComponent := range oldVersions {
+		if _, exists := newVersions[key]; !exists {
+			changes = append(changes, ComponentChange{Name: oldComponent.Name, OldVersion: oldComponent.Version})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Name < changes[j].Name
+	})
+	return changes
+}
+
+// ReconcileComponents flags components whose release notes version differs from the same-named BOSH release
+// in the tile; components without a matching release, such as the stemcell, are not compared
+func ReconcileComponents(components []Component, releases []metadata.Release) []ComponentMismatch {
+	bundled := make(map[string]metadata.Release, len(releases))
+	for _, release := range releases {
+		bundled[componentKey(release.Name)] = release
+	}
+
+	var mismatches []ComponentMismatch
+	for _, component := range components {
+		release, ok := bundled[componentKey(component.Name)]
+		if !ok || sameVersion(component.Version, release.Version) {
+			continue
+		}
+		mismatches = append(mismatches, ComponentMismatch{
+			Name:         component.Name,
+			Release:      release.Name,
+			NotesVersion: component.Version,
+			TileVersion:  release.Version,
+		})
+	}
+
+	sort.Slice(mismatches, func(i, j int) bool {
+		return mismatches[i].Name < mismatches[j].Name
+	})
+	return mismatches
+}
+
+// buildComponentMap keys components by normalized name
+func buildComponentMap(components []Component) map[string]Component {
+	componentMap := make(map[string]Component, len(components))
+	for _, component := range components {
+		componentMap[componentKey(component.Name)] = component
+	}
+	return componentMap
+}
+
+// componentKey normalizes a component or release name so "Diego Release", "diego_release", and "diego" match
+func componentKey(name string) string {
+	key := strings.ToLower(strings.TrimSpace(name))
+	key = strings.NewReplacer(" ", "-", "_", "-").Replace(key)
+	return strings.TrimSuffix(key, "-release")
+}
+
+// sameVersion compares versions semantically when both parse, ignoring a leading "v", and textually otherwise
+func sameVersion(a, b string) bool {
+	a = strings.TrimPrefix(strings.TrimSpace(a), "v")
+	b = strings.TrimPrefix(strings.TrimSpace(b), "v")
+	if cmp, err := version.Compare(a, b); err == nil {
+		return cmp == 0
+	}
+	return a == b
+}
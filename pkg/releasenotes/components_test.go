@@ -0,0 +1,133 @@
+// ABOUTME: Unit tests for component version tables in release notes.
+// ABOUTME: Covers table parsing, diffing components across releases, and reconciling with tile BOSH releases.
+package releasenotes
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/malston/tile-diff/pkg/metadata"
+)
+
+const componentTablesHTML = `<html><body>
+<h2>10.2.5</h2>
+<h3>Component Versions</h3>
+<table>
+  <thead><tr><th>Component</th><th>Version</th><th>Release Notes</th></tr></thead>
+  <tbody>
+    <tr><td>ubuntu-jammy stemcell</td><td>1.404</td><td>See stemcell notes</td></tr>
+    <tr><td>gorouter</td><td>0.301.0*</td><td></td></tr>
+    <tr><td>diego</td><td>2.99.0</td><td></td></tr>
+    <tr><td>capi</td><td>1.180.0</td><td></td></tr>
+  </tbody>
+</table>
+<h3>Compatibility</h3>
+<table>
+  <tr><th>Ops Manager</th><th>Supported versions</th></tr>
+  <tr><td>3.0</td><td>3.0.25 and later</td></tr>
+</table>
+<h2>10.2.4</h2>
+<h3>Components</h3>
+<table>
+  <tr><td>ubuntu-jammy stemcell</td><td>1.404</td></tr>
+  <tr><td>gorouter</td><td>0.300.0</td></tr>
+  <tr><td>diego</td><td>2.99.0</td></tr>
+  <tr><td>loggregator-agent</td><td>8.1.0</td></tr>
+</table>
+</body></html>`
+
+func TestParseReleaseNotes_ComponentTables(t *testing.T) {
+	notes, err := ParseReleaseNotes(componentTablesHTML)
+	if err != nil {
+		t.Fatalf("ParseReleaseNotes failed: %v", err)
+	}
+
+	if len(notes.Releases) != 2 {
+		t.Fatalf("Expected 2 releases, got %d", len(notes.Releases))
+	}
+
+	expected := []Component{
+		{Name: "ubuntu-jammy stemcell", Version: "1.404"},
+		{Name: "gorouter", Version: "0.301.0"},
+		{Name: "diego", Version: "2.99.0"},
+		{Name: "capi", Version: "1.180.0"},
+	}
+	if !reflect.DeepEqual(notes.Releases[0].Components, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, notes.Releases[0].Components)
+	}
+
+	// Headerless tables are read as name/version pairs only in a components section
+	if got := len(notes.Releases[1].Components); got != 4 {
+		t.Errorf("Expected 4 components from the headerless table, got %d", got)
+	}
+}
+
+func TestDiffComponents(t *testing.T) {
+	oldComponents := []Component{
+		{Name: "gorouter", Version: "0.300.0"},
+		{Name: "diego", Version: "2.99.0"},
+		{Name: "loggregator-agent", Version: "8.1.0"},
+	}
+	newComponents := []Component{
+		{Name: "Gorouter", Version: "v0.301.0"},
+		{Name: "diego", Version: "2.99.0"},
+		{Name: "capi", Version: "1.180.0"},
+	}
+
+	expected := []ComponentChange{
+		{Name: "Gorouter", OldVersion: "0.300.0", NewVersion: "v0.301.0"},
+		{Name: "capi", NewVersion: "1.180.0"},
+		{Name: "loggregator-agent", OldVersion: "8.1.0"},
+	}
+	if got := DiffComponents(oldComponents, newComponents); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, got)
+	}
+}
+
+func TestReleaseNotesComponentChanges(t *testing.T) {
+	notes := &ReleaseNotes{
+		Releases: []Release{
+			{Version: "10.2.5", Components: []Component{{Name: "gorouter", Version: "0.301.0"}}},
+			{Version: "10.2.6"},
+		},
+	}
+
+	if changes := notes.ComponentChanges(); changes != nil {
+		t.Errorf("Expected no changes without a baseline, got %+v", changes)
+	}
+
+	notes.Baseline = &Release{Version: "10.2.4", Components: []Component{{Name: "gorouter", Version: "0.300.0"}}}
+	expected := []ComponentChange{{Name: "gorouter", OldVersion: "0.300.0", NewVersion: "0.301.0"}}
+	if got := notes.ComponentChanges(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, got)
+	}
+
+	if got := notes.ComponentsFor("10.2.5"); len(got) != 1 {
+		t.Errorf("Expected components for 10.2.5, got %+v", got)
+	}
+	if got := notes.ComponentsFor("10.2.6"); got != nil {
+		t.Errorf("Expected no components for 10.2.6, got %+v", got)
+	}
+}
+
+func TestReconcileComponents(t *testing.T) {
+	components := []Component{
+		{Name: "ubuntu-jammy stemcell", Version: "1.404"},
+		{Name: "gorouter", Version: "0.301.0"},
+		{Name: "Diego Release", Version: "2.99.0"},
+		{Name: "capi", Version: "1.180.0"},
+	}
+	releases := []metadata.Release{
+		{Name: "gorouter", Version: "0.300.0"},
+		{Name: "diego", Version: "2.99.0"},
+		{Name: "capi_release", Version: "v1.180.0"},
+		{Name: "routing", Version: "0.301.0"},
+	}
+
+	expected := []ComponentMismatch{
+		{Name: "gorouter", Release: "gorouter", NotesVersion: "0.301.0", TileVersion: "0.300.0"},
+	}
+	if got := ReconcileComponents(components, releases); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, got)
+	}
+}
@@ -0,0 +1,132 @@
+// ABOUTME: Structured model of a release notes page: releases, typed sections, and items.
+// ABOUTME: Classifies section headings and flattens the tree into features and notes.
+package releasenotes
+
+import "strings"
+
+// SectionKind classifies a release notes section by its heading
+type SectionKind string
+
+const (
+	SectionFeatures        SectionKind = "features"
+	SectionBreakingChanges SectionKind = "breaking-changes"
+	SectionDeprecations    SectionKind = "deprecations"
+	SectionKnownIssues     SectionKind = "known-issues"
+	SectionResolvedIssues  SectionKind = "resolved-issues"
+	SectionSecurityFixes   SectionKind = "security-fixes"
+	SectionComponents      SectionKind = "components"
+	SectionOther           SectionKind = "other"
+)
+
+// ReleaseNotes holds the releases described by one or more release notes pages
+type ReleaseNotes struct {
+	Releases []Release
+	Baseline *Release // The release being upgraded from, when its notes were fetched
+}
+
+// Release is one version's entry in the release notes
+type Release struct {
+	Version    string // Empty for content not under a version heading
+	Title      string
+	Sections   []Section
+	Components []Component // Rows of the release's component version table
+}
+
+// Section is a headed group of items within a release, such as "Known Issues"
+type Section struct {
+	Title string
+	Kind  SectionKind
+	Items []Item
+}
+
+// Item is a single bullet, block of prose, or sub-headed entry in a section
+type Item struct {
+	Title string
+	Text  string
+}
+
+// Note is a release notes item with the release and section it came from
+type Note struct {
+	Version string
+	Kind    SectionKind
+	Section string
+	Title   string
+	Text    string
+}
+
+// sectionKeywords maps heading keywords to section kinds, checked in order
+var sectionKeywords = []struct {
+	keywords []string
+	kind     SectionKind
+}{
+	{[]string{"breaking"}, SectionBreakingChanges},
+	{[]string{"deprecat"}, SectionDeprecations},
+	{[]string{"known issue"}, SectionKnownIssues},
+	{[]string{"security fix", "security issue", "security update", "vulnerabilit", "cve"}, SectionSecurityFixes},
+	{[]string{"resolved issue", "fixed issue", "bug fix", "fixes"}, SectionResolvedIssues},
+	{[]string{"component"}, SectionComponents},
+	{[]string{"feature", "what's new", "new in", "enhancement", "improvement"}, SectionFeatures},
+}
+
+// ClassifySection determines the kind of section from its heading
+func ClassifySection(title string) SectionKind {
+	lower := strings.ToLower(title)
+	for _, entry := range sectionKeywords {
+		for _, keyword := range entry.keywords {
+			if strings.Contains(lower, keyword) {
+				return entry.kind
+			}
+		}
+	}
+	return SectionOther
+}
+
+// Features flattens the notes into one feature per item, titled by the item's sub-heading or its section
+func (n *ReleaseNotes) Features() []Feature {
+	var features []Feature
+	for _, note := range n.Notes() {
+		title := note.Title
+		if title == "" {
+			title = note.Section
+		}
+		description := note.Text
+		if description == "" {
+			description = note.Title
+		}
+		features = append(features, Feature{
+			Title:       title,
+			Description: description,
+			Position:    len(features) + 1,
+			Version:     note.Version,
+			Kind:        note.Kind,
+		})
+	}
+	return features
+}
+
+// Notes returns every item, or only items in sections of the given kinds, in document order
+func (n *ReleaseNotes) Notes(kinds ...SectionKind) []Note {
+	wanted := make(map[SectionKind]bool, len(kinds))
+	for _, kind := range kinds {
+		wanted[kind] = true
+	}
+
+	var notes []Note
+	for _, release := range n.Releases {
+		for _, section := range release.Sections {
+			if len(kinds) > 0 && !wanted[section.Kind] {
+				continue
+			}
+			for _, item := range section.Items {
+				notes = append(notes, Note{
+					Version: release.Version,
+					Kind:    section.Kind,
+					Section: section.Title,
+					Title:   item.Title,
+					Text:    item.Text,
+				})
+			}
+		}
+	}
+	return notes
+}
@@ -0,0 +1,137 @@
+// ABOUTME: Unit tests for the structured release notes model.
+// ABOUTME: Validates the release -> section -> item tree, section classification, and flattening.
+package releasenotes
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestParseReleaseNotes(t *testing.T) {
+	content, err := os.ReadFile("testdata/structured-release-notes.html")
+	if err != nil {
+		t.Fatalf("Failed to read test data: %v", err)
+	}
+
+	notes, err := ParseReleaseNotes(string(content))
+	if err != nil {
+		t.Fatalf("ParseReleaseNotes failed: %v", err)
+	}
+
+	if len(notes.Releases) != 2 {
+		t.Fatalf("Expected 2 releases, got %d", len(notes.Releases))
+	}
+
+	latest := notes.Releases[0]
+	if latest.Version != "10.2.5" {
+		t.Errorf("Expected version 10.2.5, got %s", latest.Version)
+	}
+
+	var kinds []SectionKind
+	for _, section := range latest.Sections {
+		kinds = append(kinds, section.Kind)
+	}
+	expectedKinds := []SectionKind{SectionOther, SectionFeatures, SectionBreakingChanges, SectionKnownIssues, SectionResolvedIssues}
+	if !reflect.DeepEqual(kinds, expectedKinds) {
+		t.Errorf("Expected section kinds %v, got %v", expectedKinds, kinds)
+	}
+
+	if items := latest.Sections[1].Items; len(items) != 2 {
+		t.Errorf("Expected 2 feature items, got %d", len(items))
+	}
+
+	knownIssue := latest.Sections[3].Items
+	expectedIssue := []Item{{
+		Title: "Diego cells may restart during upgrade",
+		Text:  "When upgrading from 6.0, Diego cells can restart twice. Upgrade Ops Manager first to avoid this.",
+	}}
+	if !reflect.DeepEqual(knownIssue, expectedIssue) {
+		t.Errorf("Expected known issue %+v, got %+v", expectedIssue, knownIssue)
+	}
+
+	previous := notes.Releases[1]
+	if previous.Version != "10.2.4" || len(previous.Sections) != 1 || previous.Sections[0].Kind != SectionDeprecations {
+		t.Errorf("Unexpected 10.2.4 release: %+v", previous)
+	}
+	if len(previous.Sections[0].Items) != 1 {
+		t.Errorf("Expected table contents to be excluded from items, got %+v", previous.Sections[0].Items)
+	}
+}
+
+func TestClassifySection(t *testing.T) {
+	tests := map[string]SectionKind{
+		"New Features":                SectionFeatures,
+		"What's New":                  SectionFeatures,
+		"Breaking Changes":            SectionBreakingChanges,
+		"Deprecated Features":         SectionDeprecations,
+		"Known Issues":                SectionKnownIssues,
+		"Resolved Issues":             SectionResolvedIssues,
+		"Bug Fixes":                   SectionResolvedIssues,
+		"Security Fixes":              SectionSecurityFixes,
+		"Resolved CVEs":               SectionSecurityFixes,
+		"Component Versions":          SectionComponents,
+		"Enhanced Security Scanning":  SectionOther,
+		"Release Date: March 3, 2026": SectionOther,
+	}
+
+	for title, expected := range tests {
+		if got := ClassifySection(title); got != expected {
+			t.Errorf("ClassifySection(%q) = %s, expected %s", title, got, expected)
+		}
+	}
+}
+
+func TestReleaseNotesNotes(t *testing.T) {
+	content, err := os.ReadFile("testdata/structured-release-notes.html")
+	if err != nil {
+		t.Fatalf("Failed to read test data: %v", err)
+	}
+	notes, err := ParseReleaseNotes(string(content))
+	if err != nil {
+		t.Fatalf("ParseReleaseNotes failed: %v", err)
+	}
+
+	upgradeNotes := notes.Notes(SectionBreakingChanges, SectionKnownIssues, SectionDeprecations)
+
+	var got []string
+	for _, note := range upgradeNotes {
+		got = append(got, note.Version+" "+string(note.Kind))
+	}
+	expected := []string{"10.2.5 breaking-changes", "10.2.5 known-issues", "10.2.4 deprecations"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+}
+
+func TestReleaseNotesFeatures(t *testing.T) {
+	content, err := os.ReadFile("testdata/structured-release-notes.html")
+	if err != nil {
+		t.Fatalf("Failed to read test data: %v", err)
+	}
+
+	features, err := ParseHTML(string(content))
+	if err != nil {
+		t.Fatalf("ParseHTML failed: %v", err)
+	}
+
+	// Each bullet becomes its own feature instead of one blob per heading
+	var feature Feature
+	for _, f := range features {
+		if f.Kind == SectionFeatures && f.Position == 2 {
+			feature = f
+		}
+	}
+	if feature.Title != "New Features" || feature.Version != "10.2.5" {
+		t.Errorf("Unexpected first feature item: %+v", feature)
+	}
+	if feature.Description != "Adds the router_keepalive_timeout property to tune Gorouter idle connections." {
+		t.Errorf("Expected a single bullet as the description, got %q", feature.Description)
+	}
+
+	for _, f := range features {
+		if f.Title == "Diego cells may restart during upgrade" && f.Kind != SectionKnownIssues {
+			t.Errorf("Expected sub-headed item to keep its section kind, got %s", f.Kind)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+// ABOUTME: Evaluation harness measuring matcher precision and recall on the labelled fixtures in testdata/matching.
+// ABOUTME: Reports precision and recall per fixture and fails if either drops below the accepted floor.
+package releasenotes
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Minimum precision and recall the matcher must keep on every fixture
+const (
+	minPrecision = 0.9
+	minRecall    = 0.8
+)
+
+// EvaluationSet is a labelled fixture: release notes features and the properties expected to match them
+type EvaluationSet struct {
+	Name     string              `yaml:"name"`
+	Features []EvaluationFeature `yaml:"features"`
+	Cases    []EvaluationCase    `yaml:"cases"`
+}
+
+// EvaluationFeature is a release notes feature in a fixture
+type EvaluationFeature struct {
+	Title       string `yaml:"title"`
+	Description string `yaml:"description"`
+}
+
+// EvaluationCase is a property, its form context, and the title of the feature it should match (empty for none)
+type EvaluationCase struct {
+	Property string `yaml:"property"`
+	Context  string `yaml:"context,omitempty"`
+	Expect   string `yaml:"expect,omitempty"`
+}
+
+// EvaluationResult counts matcher outcomes against the labels
+type EvaluationResult struct {
+	TruePositives  int
+	FalsePositives int
+	FalseNegatives int
+	TrueNegatives  int
+	Errors         []string // One line per mislabelled property
+}
+
+// LoadEvaluationSet reads a labelled fixture from YAML
+func LoadEvaluationSet(path string) (*EvaluationSet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read evaluation set: %w", err)
+	}
+
+	var set EvaluationSet
+	if err := yaml.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("failed to parse evaluation set %s: %w", path, err)
+	}
+	return &set, nil
+}
+
+// Evaluate matches every case's property against the fixture's features and scores the result.
+// A match to the wrong feature counts as both a false positive and a false negative.
+func (s *EvaluationSet) Evaluate() EvaluationResult {
+	features := make([]Feature, len(s.Features))
+	for i, feature := range s.Features {
+		features[i] = Feature{Title: feature.Title, Description: feature.Description, Position: i + 1}
+	}
+
+	context := make(map[string]string)
+	properties := make([]string, len(s.Cases))
+	for i, c := range s.Cases {
+		properties[i] = c.Property
+		context[c.Property] = c.Context
+	}
+
+	matches := NewMatcher(features).WithContext(context).Match(properties)
+
+	var result EvaluationResult
+	for _, c := range s.Cases {
+		match, matched := matches[c.Property]
+		switch {
+		case matched && match.Feature.Title == c.Expect:
+			result.TruePositives++
+		case matched && c.Expect == "":
+			result.FalsePositives++
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: expected no match, got %q (%s)", c.Property, match.Feature.Title, match.Explanation))
+		case matched:
+			result.FalsePositives++
+			result.FalseNegatives++
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: expected %q, got %q (%s)", c.Property, c.Expect, match.Feature.Title, match.Explanation))
+		case c.Expect != "":
+			result.FalseNegatives++
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: expected %q, got no match", c.Property, c.Expect))
+		default:
+			result.TrueNegatives++
+		}
+	}
+	return result
+}
+
+// Precision is the fraction of reported matches that are correct, or 1 when nothing was matched
+func (r EvaluationResult) Precision() float64 {
+	if r.TruePositives+r.FalsePositives == 0 {
+		return 1
+	}
+	return float64(r.TruePositives) / float64(r.TruePositives+r.FalsePositives)
+}
+
+// Recall is the fraction of expected matches that were found, or 1 when none were expected
+func (r EvaluationResult) Recall() float64 {
+	if r.TruePositives+r.FalseNegatives == 0 {
+		return 1
+	}
+	return float64(r.TruePositives) / float64(r.TruePositives+r.FalseNegatives)
+}
+
+func TestMatcherEvaluation(t *testing.T) {
+	paths, err := filepath.Glob("testdata/matching/*.yaml")
+	if err != nil || len(paths) == 0 {
+		t.Fatalf("No evaluation fixtures found: %v", err)
+	}
+
+	for _, path := range paths {
+		set, err := LoadEvaluationSet(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		t.Run(set.Name, func(t *testing.T) {
+			result := set.Evaluate()
+			t.Logf("precision %.2f recall %.2f (tp %d, fp %d, fn %d, tn %d)",
+				result.Precision(), result.Recall(),
+				result.TruePositives, result.FalsePositives, result.FalseNegatives, result.TrueNegatives)
+			if len(result.Errors) > 0 {
+				t.Logf("mismatches:\n  %s", strings.Join(result.Errors, "\n  "))
+			}
+
+			if result.Precision() < minPrecision || result.Recall() < minRecall {
+				t.Errorf("Expected precision >= %.2f and recall >= %.2f, got %.2f and %.2f",
+					minPrecision, minRecall, result.Precision(), result.Recall())
+			}
+		})
+	}
+}
+
+func TestEvaluationResult(t *testing.T) {
+	result := EvaluationResult{TruePositives: 8, FalsePositives: 2, FalseNegatives: 8}
+	if result.Precision() != 0.8 || result.Recall() != 0.5 {
+		t.Errorf("Expected precision 0.8 and recall 0.5, got %.2f and %.2f", result.Precision(), result.Recall())
+	}
+
+	if empty := (EvaluationResult{}); empty.Precision() != 1 || empty.Recall() != 1 {
+		t.Error("Expected perfect scores when nothing is expected or matched")
+	}
+}
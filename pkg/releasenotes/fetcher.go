@@ -1,55 +1,152 @@
-// ABOUTME: HTTP client for fetching release notes from documentation sites.
-// ABOUTME: Implements in-memory caching and timeout handling.
+// ABOUTME: HTTP client for fetching release notes from documentation sites or a pluggable source.
+// ABOUTME: Caches pages in memory and optionally on disk, with revalidation and an offline mode.
 package releasenotes
 
 import (
 	"fmt"
 	"io"
 	"net/http"
+	"sync"
 	"time"
 )
 
-// Fetcher fetches and caches release notes HTML
+// Fetcher fetches and caches release notes HTML; it is safe for concurrent use
 type Fetcher struct {
-	client *http.Client
-	cache  map[string]string
+	client  *http.Client
+	disk    *PageCache
+	offline bool
+	source  Source // When set, pages are read from it instead of over HTTP
+
+	mu    sync.Mutex
+	cache map[string]string
 }
 
-// NewFetcher creates a new release notes fetcher
+// NewFetcher creates a new release notes fetcher that caches pages in memory only
 func NewFetcher() *Fetcher {
+	return NewCachingFetcher(nil, false)
+}
+
+// NewCachingFetcher creates a fetcher backed by a disk cache; in offline mode only cached pages are returned
+func NewCachingFetcher(disk *PageCache, offline bool) *Fetcher {
 	return &Fetcher{
 		client: &http.Client{
 			Timeout: 10 * time.Second,
 		},
-		cache: make(map[string]string),
+		disk:    disk,
+		offline: offline,
+		cache:   make(map[string]string),
 	}
 }
 
+// NewSourceFetcher creates a fetcher that reads pages from source, such as a directory of saved pages
+func NewSourceFetcher(source Source) *Fetcher {
+	fetcher := NewFetcher()
+	fetcher.source = source
+	return fetcher
+}
+
 // Fetch retrieves release notes HTML from URL (with caching)
 func (f *Fetcher) Fetch(url string) (string, error) {
-	// Check cache
-	if cached, ok := f.cache[url]; ok {
+	// Check memory cache
+	f.mu.Lock()
+	cached, ok := f.cache[url]
+	f.mu.Unlock()
+	if ok {
 		return cached, nil
 	}
 
-	// Fetch from URL
-	resp, err := f.client.Get(url)
+	var html string
+	var err error
+	if f.source != nil {
+		html, err = f.source.Read(url)
+	} else {
+		html, err = f.fetch(url)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	f.mu.Lock()
+	f.cache[url] = html
+	f.mu.Unlock()
+
+	return html, nil
+}
+
+// fetch consults the disk cache, revalidating stale pages with the server when online
+func (f *Fetcher) fetch(url string) (string, error) {
+	var stored *CachedPage
+	if f.disk != nil {
+		stored, _ = f.disk.Get(url)
+	}
+
+	if f.offline {
+		if stored == nil {
+			return "", fmt.Errorf("release notes for %s are not cached (offline mode)", url)
+		}
+		return stored.Body, nil
+	}
+	if stored != nil && f.disk.Fresh(stored) {
+		return stored.Body, nil
+	}
+
+	page, err := f.download(url, stored)
 	if err != nil {
-		return "", fmt.Errorf("failed to fetch %s: %w", url, err)
+		// A stale copy is better than none when the page cannot be refreshed
+		if stored != nil {
+			return stored.Body, nil
+		}
+		return "", err
+	}
+
+	if f.disk != nil {
+		// Failing to persist only costs a refetch next run
+		_ = f.disk.Put(page)
+	}
+	return page.Body, nil
+}
+
+// download fetches url, sending stored's validators so an unchanged page is answered with 304 Not Modified
+func (f *Fetcher) download(url string, stored *CachedPage) (*CachedPage, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", url, err)
+	}
+	if stored != nil {
+		if stored.ETag != "" {
+			req.Header.Set("If-None-Match", stored.ETag)
+		}
+		if stored.LastModified != "" {
+			req.Header.Set("If-Modified-Since", stored.LastModified)
+		}
+	}
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", url, err)
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusNotModified && stored != nil {
+		refreshed := *stored
+		refreshed.FetchedAt = time.Now().UTC()
+		return &refreshed, nil
+	}
+
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("HTTP %d fetching %s", resp.StatusCode, url)
+		return nil, fmt.Errorf("HTTP %d fetching %s", resp.StatusCode, url)
 	}
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return "", fmt.Errorf("failed to read response: %w", err)
+		return nil, fmt.Errorf("failed to read response: %w", err)
 	}
 
-	html := string(body)
-	f.cache[url] = html
-
-	return html, nil
+	return &CachedPage{
+		URL:          url,
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+		FetchedAt:    time.Now().UTC(),
+		Body:         string(body),
+	}, nil
 }
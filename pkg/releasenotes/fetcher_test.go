@@ -5,7 +5,9 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
 func TestFetchReleaseNotes(t *testing.T) {
@@ -67,6 +69,118 @@ func TestFetchReleaseNotes_Cache(t *testing.T) {
 	}
 }
 
+func TestFetchReleaseNotes_DiskCache(t *testing.T) {
+	requests, notModified := 0, 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte("<html><body>Release Notes</body></html>"))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+
+	// A fresh page is served from disk by a later run without contacting the server
+	if _, err := NewCachingFetcher(NewPageCache(dir, time.Hour), false).Fetch(server.URL); err != nil {
+		t.Fatalf("First fetch failed: %v", err)
+	}
+	if _, err := NewCachingFetcher(NewPageCache(dir, time.Hour), false).Fetch(server.URL); err != nil {
+		t.Fatalf("Cached fetch failed: %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("Expected 1 HTTP call, got %d", requests)
+	}
+
+	// A stale page is revalidated with its ETag and reused when unchanged
+	html, err := NewCachingFetcher(NewPageCache(dir, 0), false).Fetch(server.URL)
+	if err != nil {
+		t.Fatalf("Revalidating fetch failed: %v", err)
+	}
+	if notModified != 1 || !contains(html, "Release Notes") {
+		t.Errorf("Expected a 304 revalidation returning the cached page, got %d 304s and %q", notModified, html)
+	}
+}
+
+func TestFetchReleaseNotes_StaleOnError(t *testing.T) {
+	failing := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if failing {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("<html><body>Release Notes</body></html>"))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	if _, err := NewCachingFetcher(NewPageCache(dir, 0), false).Fetch(server.URL); err != nil {
+		t.Fatalf("First fetch failed: %v", err)
+	}
+
+	failing = true
+	html, err := NewCachingFetcher(NewPageCache(dir, 0), false).Fetch(server.URL)
+	if err != nil || !contains(html, "Release Notes") {
+		t.Errorf("Expected stale cached page when the server fails, got %q, %v", html, err)
+	}
+}
+
+func TestFetchReleaseNotes_Offline(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte("<html><body>Release Notes</body></html>"))
+	}))
+	defer server.Close()
+
+	cache := NewPageCache(t.TempDir(), 0)
+	if err := cache.Put(&CachedPage{URL: server.URL + "/seeded", FetchedAt: time.Now().Add(-30 * 24 * time.Hour), Body: "<html>Seeded</html>"}); err != nil {
+		t.Fatal(err)
+	}
+
+	fetcher := NewCachingFetcher(cache, true)
+
+	html, err := fetcher.Fetch(server.URL + "/seeded")
+	if err != nil || html != "<html>Seeded</html>" {
+		t.Errorf("Expected seeded page regardless of age, got %q, %v", html, err)
+	}
+
+	if _, err := fetcher.Fetch(server.URL + "/missing"); err == nil || !strings.Contains(err.Error(), "offline") {
+		t.Errorf("Expected offline error for uncached page, got %v", err)
+	}
+
+	if requests != 0 {
+		t.Errorf("Expected no HTTP calls in offline mode, got %d", requests)
+	}
+}
+
+func TestFetchReleaseNotes_Concurrent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("<html><body>" + r.URL.Path + "</body></html>"))
+	}))
+	defer server.Close()
+
+	fetcher := NewCachingFetcher(NewPageCache(t.TempDir(), time.Hour), false)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			path := []string{"/a", "/b", "/c"}[i%3]
+			html, err := fetcher.Fetch(server.URL + path)
+			if err != nil || !contains(html, path) {
+				t.Errorf("Unexpected result for %s: %q, %v", path, html, err)
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
 func contains(s, substr string) bool {
 	return strings.Contains(s, substr)
 }
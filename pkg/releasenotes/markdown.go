@@ -0,0 +1,97 @@
+// ABOUTME: Minimal Markdown to HTML conversion for release notes saved as Markdown.
+// ABOUTME: Handles headings, lists, pipe tables, and paragraphs, which is all the release notes parser reads.
+package releasenotes
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+)
+
+var (
+	mdHeadingPattern   = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	mdListItemPattern  = regexp.MustCompile(`^\s*(?:[-*+]|\d+[.)])\s+(.*)$`)
+	mdTableRulePattern = regexp.MustCompile(`^\|?\s*:?-{3,}:?\s*(\|\s*:?-{3,}:?\s*)*\|?\s*$`)
+	mdLinkPattern      = regexp.MustCompile(`\[([^\]]*)\]\([^)]*\)`)
+)
+
+// MarkdownToHTML converts the block structure of a Markdown release notes page to HTML
+func MarkdownToHTML(markdown string) string {
+	var sb strings.Builder
+	var paragraph []string
+	inList, inTable := false, false
+
+	closeBlocks := func() {
+		if len(paragraph) > 0 {
+			sb.WriteString("<p>" + strings.Join(paragraph, " ") + "</p>\n")
+			paragraph = nil
+		}
+		if inList {
+			sb.WriteString("</ul>\n")
+			inList = false
+		}
+		if inTable {
+			sb.WriteString("</table>\n")
+			inTable = false
+		}
+	}
+
+	lines := strings.Split(strings.ReplaceAll(markdown, "\r\n", "\n"), "\n")
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case trimmed == "":
+			closeBlocks()
+
+		case mdHeadingPattern.MatchString(trimmed):
+			closeBlocks()
+			match := mdHeadingPattern.FindStringSubmatch(trimmed)
+			sb.WriteString(fmt.Sprintf("<h%d>%s</h%d>\n", len(match[1]), markdownInline(match[2]), len(match[1])))
+
+		case strings.HasPrefix(trimmed, "|"):
+			if mdTableRulePattern.MatchString(trimmed) {
+				continue
+			}
+			if !inTable {
+				closeBlocks()
+				sb.WriteString("<table>\n")
+				inTable = true
+			}
+			cell := "td"
+			if i+1 < len(lines) && mdTableRulePattern.MatchString(strings.TrimSpace(lines[i+1])) {
+				cell = "th"
+			}
+			sb.WriteString("<tr>")
+			for _, value := range strings.Split(strings.Trim(trimmed, "|"), "|") {
+				sb.WriteString(fmt.Sprintf("<%s>%s</%s>", cell, markdownInline(strings.TrimSpace(value)), cell))
+			}
+			sb.WriteString("</tr>\n")
+
+		case mdListItemPattern.MatchString(line):
+			if !inList {
+				closeBlocks()
+				sb.WriteString("<ul>\n")
+				inList = true
+			}
+			sb.WriteString("<li>" + markdownInline(mdListItemPattern.FindStringSubmatch(line)[1]) + "</li>\n")
+
+		default:
+			if inList || inTable {
+				closeBlocks()
+			}
+			paragraph = append(paragraph, markdownInline(trimmed))
+		}
+	}
+	closeBlocks()
+
+	return sb.String()
+}
+
+// markdownInline reduces links to their text, drops emphasis and code markers, and escapes the result
+func markdownInline(text string) string {
+	text = mdLinkPattern.ReplaceAllString(text, "$1")
+	text = strings.NewReplacer("**", "", "__", "", "`", "").Replace(text)
+	return html.EscapeString(text)
+}
@@ -0,0 +1,68 @@
+// ABOUTME: Unit tests for converting Markdown release notes to HTML.
+// ABOUTME: Verifies converted pages parse into the same releases, sections, items, and components.
+package releasenotes
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMarkdownToHTML(t *testing.T) {
+	markdown := `# Elastic Application Runtime 10.2 Release Notes
+
+## 10.2.5
+
+Release Date: March 3, 2026
+
+### Breaking Changes
+
+* TLS 1.1 is no longer accepted by the **Gorouter**.
+* See [the upgrade guide](https://docs.example.com/upgrade) for ` + "`min_tls_version`" + `.
+
+### Component Versions
+
+| Component | Version |
+|-----------|--------:|
+| gorouter  | 0.301.0 |
+| diego     | 2.99.0  |
+
+## 10.2.4
+
+### Known Issues
+
+#### Diego cells may restart
+When upgrading from 6.0, Diego cells can restart twice.
+Upgrade Ops Manager first.
+`
+
+	notes, err := ParseReleaseNotes(MarkdownToHTML(markdown))
+	if err != nil {
+		t.Fatalf("ParseReleaseNotes failed: %v", err)
+	}
+
+	var got []string
+	for _, note := range notes.Notes() {
+		got = append(got, strings.Join([]string{note.Version, string(note.Kind), note.Title, note.Text}, "|"))
+	}
+	expected := []string{
+		"10.2.5|other||Release Date: March 3, 2026",
+		"10.2.5|breaking-changes||TLS 1.1 is no longer accepted by the Gorouter.",
+		"10.2.5|breaking-changes||See the upgrade guide for min_tls_version.",
+		"10.2.4|known-issues|Diego cells may restart|When upgrading from 6.0, Diego cells can restart twice. Upgrade Ops Manager first.",
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected:\n%s\ngot:\n%s", strings.Join(expected, "\n"), strings.Join(got, "\n"))
+	}
+
+	components := []Component{{Name: "gorouter", Version: "0.301.0"}, {Name: "diego", Version: "2.99.0"}}
+	if !reflect.DeepEqual(notes.Releases[0].Components, components) {
+		t.Errorf("Expected components %+v, got %+v", components, notes.Releases[0].Components)
+	}
+}
+
+func TestMarkdownToHTML_Escapes(t *testing.T) {
+	if got := MarkdownToHTML("Use <b>bold</b> & more"); got != "<p>Use &lt;b&gt;bold&lt;/b&gt; &amp; more</p>\n" {
+		t.Errorf("Unexpected HTML: %q", got)
+	}
+}
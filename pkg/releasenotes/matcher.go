@@ -1,132 +1,388 @@
-// ABOUTME: Property-to-feature matching using multiple strategies.
-// ABOUTME: Implements direct, keyword, and proximity matching with confidence scoring.
+// ABOUTME: Property-to-feature matching with TF-IDF scoring over release notes features.
+// ABOUTME: Expands property tokens with form labels and abbreviations and explains each candidate's score.
 package releasenotes
 
 import (
+	"fmt"
+	"math"
+	"sort"
 	"strings"
+	"unicode"
 )
 
+// DefaultCandidates is how many scored features are kept per property
+const DefaultCandidates = 3
+
+// MatchThreshold is the minimum keyword score for a feature to be reported as a property's match
+const MatchThreshold = 0.4
+
+// contextWeight scales terms from form labels and descriptions relative to terms in the property name
+const contextWeight = 0.5
+
+// preferredBoost scales keyword scores of features in preferred sections, such as deprecations for a removed property
+const preferredBoost = 1.25
+
 // Match represents a property matched to a feature
 type Match struct {
-	Property   string
-	Feature    Feature
-	MatchType  string  // "direct", "keyword", "proximity"
-	Confidence float64 // 0.0-1.0
+	Property    string
+	Feature     Feature
+	MatchType   string  // "direct", "keyword"
+	Confidence  float64 // 0.0-1.0
+	Explanation string
+	Candidates  []Candidate // Best-scoring features, best first, starting with the match
+}
+
+// Candidate is a feature scored against a property
+type Candidate struct {
+	Feature     Feature
+	MatchType   string
+	Score       float64
+	Explanation string
 }
 
 // Matcher matches properties to features
 type Matcher struct {
 	features []Feature
+	vectors  []map[string]float64 // Normalized TF-IDF vector of each feature
+	idf      map[string]float64
+	unseen   float64           // IDF of a term no feature contains
+	surface  map[string]string // A word from the release notes for each term, for explanations
+	context  map[string]string
 }
 
-// NewMatcher creates a new matcher with features
+// NewMatcher creates a new matcher with features, weighting terms by how rarely they occur across features
 func NewMatcher(features []Feature) *Matcher {
-	return &Matcher{features: features}
+	m := &Matcher{features: features, idf: make(map[string]float64), surface: make(map[string]string)}
+
+	documents := make([][]string, len(features))
+	df := make(map[string]int)
+	for i, feature := range features {
+		analyze(feature.Title+" "+feature.Description, func(term, word string) {
+			documents[i] = append(documents[i], term)
+			if _, ok := m.surface[term]; !ok {
+				m.surface[term] = word
+			}
+		})
+		seen := make(map[string]bool)
+		for _, term := range documents[i] {
+			if !seen[term] {
+				seen[term] = true
+				df[term]++
+			}
+		}
+	}
+
+	n := float64(len(features))
+	for term, count := range df {
+		m.idf[term] = math.Log((n+1)/(float64(count)+1)) + 1
+	}
+	m.unseen = math.Log(n+1) + 1
+
+	for _, document := range documents {
+		vector := make(map[string]float64)
+		for _, term := range document {
+			vector[term] += m.idf[term]
+		}
+		m.vectors = append(m.vectors, normalize(vector))
+	}
+
+	return m
+}
+
+// WithContext adds text describing each property, such as its form label and description, to expand its terms
+func (m *Matcher) WithContext(context map[string]string) *Matcher {
+	m.context = context
+	return m
 }
 
 // Match finds matches for properties
 func (m *Matcher) Match(properties []string) map[string]Match {
+	return m.MatchPreferring(properties)
+}
+
+// MatchPreferring finds matches for properties, ranking features in sections of the given kinds first
+func (m *Matcher) MatchPreferring(properties []string, kinds ...SectionKind) map[string]Match {
 	matches := make(map[string]Match)
 
 	for _, prop := range properties {
-		if match, found := m.findBestMatch(prop); found {
-			matches[prop] = match
+		candidates := m.CandidatesPreferring(prop, DefaultCandidates, kinds...)
+		if len(candidates) == 0 {
+			continue
+		}
+		best := candidates[0]
+		if best.MatchType != "direct" && best.Score < MatchThreshold {
+			continue
+		}
+		matches[prop] = Match{
+			Property:    prop,
+			Feature:     best.Feature,
+			MatchType:   best.MatchType,
+			Confidence:  best.Score,
+			Explanation: best.Explanation,
+			Candidates:  candidates,
 		}
 	}
 
 	return matches
 }
 
-var stopwords = map[string]bool{
-	"enable":     true,
-	"enabled":    true,
-	"setting":    true,
-	"settings":   true,
-	"config":     true,
-	"configure":  true,
-	"new":        true,
-	"property":   true,
-	"properties": true,
-}
-
-func (m *Matcher) findBestMatch(property string) (Match, bool) {
-	var bestMatch Match
-	bestConfidence := 0.0
-
-	// Try direct matching first
-	for _, feature := range m.features {
-		if strings.Contains(feature.Description, property) {
-			match := Match{
-				Property:   property,
-				Feature:    feature,
-				MatchType:  "direct",
-				Confidence: 1.0,
-			}
-			return match, true
-		}
-	}
-
-	// Try keyword matching
-	tokens := tokenizeProperty(property)
-	if len(tokens) >= 2 {
-		for _, feature := range m.features {
-			score := keywordScore(tokens, feature)
-			if score > bestConfidence {
-				bestConfidence = score
-				bestMatch = Match{
-					Property:   property,
-					Feature:    feature,
-					MatchType:  "keyword",
-					Confidence: score,
-				}
-			}
+// Candidates scores every feature against a property and returns the best n, best first. Features naming
+// the property outright score 1.0; others score by the cosine similarity of their TF-IDF vectors with the
+// property's terms, and only if they share at least one term with the property name. Ties keep release order.
+func (m *Matcher) Candidates(property string, n int) []Candidate {
+	return m.CandidatesPreferring(property, n)
+}
+
+// CandidatesPreferring scores features like Candidates, boosting keyword scores of features in sections of
+// the given kinds and ranking them first among equal scores, so a removed property's deprecation notice
+// wins over a feature that merely mentions it
+func (m *Matcher) CandidatesPreferring(property string, n int, kinds ...SectionKind) []Candidate {
+	preferred := make(map[SectionKind]bool, len(kinds))
+	for _, kind := range kinds {
+		preferred[kind] = true
+	}
+
+	nameTerms := tokenizeProperty(property)
+	query := make(map[string]float64)
+	for _, term := range nameTerms {
+		query[term] += m.weight(term)
+	}
+	for _, term := range terms(m.context[property]) {
+		query[term] += contextWeight * m.weight(term)
+	}
+	query = normalize(query)
+
+	isNameTerm := make(map[string]bool, len(nameTerms))
+	for _, term := range nameTerms {
+		isNameTerm[term] = true
+	}
+
+	var candidates []Candidate
+	for i, feature := range m.features {
+		if mentionsProperty(feature, property) {
+			candidates = append(candidates, Candidate{
+				Feature:     feature,
+				MatchType:   "direct",
+				Score:       1.0,
+				Explanation: fmt.Sprintf("release note names %s", bareProperty(property)),
+			})
+			continue
+		}
+
+		score, shared := cosine(query, m.vectors[i])
+		if score == 0 || !sharesAny(shared, isNameTerm) {
+			continue
 		}
+		explanation := m.explain(score, shared, isNameTerm)
+		if preferred[feature.Kind] {
+			score *= preferredBoost
+			explanation += fmt.Sprintf("; boosted for %s section", feature.Kind)
+		}
+		candidates = append(candidates, Candidate{
+			Feature:     feature,
+			MatchType:   "keyword",
+			Score:       math.Min(score, 0.99),
+			Explanation: explanation,
+		})
 	}
 
-	// Only return if confidence > 0.5
-	if bestConfidence > 0.5 {
-		return bestMatch, true
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].Score != candidates[j].Score {
+			return candidates[i].Score > candidates[j].Score
+		}
+		return preferred[candidates[i].Feature.Kind] && !preferred[candidates[j].Feature.Kind]
+	})
+	if len(candidates) > n {
+		candidates = candidates[:n]
 	}
+	return candidates
+}
 
-	return bestMatch, false
+// weight returns a term's IDF, treating terms absent from every feature as the rarest
+func (m *Matcher) weight(term string) float64 {
+	if idf, ok := m.idf[term]; ok {
+		return idf
+	}
+	return m.unseen
 }
 
+var stopwords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true, "be": true, "by": true,
+	"can": true, "for": true, "from": true, "in": true, "is": true, "it": true, "its": true, "now": true,
+	"of": true, "on": true, "or": true, "that": true, "the": true, "this": true, "to": true, "with": true,
+	"you": true, "your": true, "when": true, "will": true, "no": true, "not": true,
+	"add": true, "adds": true, "added": true, "allow": true, "allows": true, "use": true, "uses": true,
+	"enable": true, "enabled": true, "setting": true, "settings": true, "config": true, "configure": true,
+	"configurable": true, "new": true, "property": true, "properties": true, "operators": true,
+}
+
+// abbreviations expands shorthand common in property names to the words release notes use
+var abbreviations = map[string][]string{
+	"cc":   {"cloud", "controller"},
+	"db":   {"database"},
+	"app":  {"application"},
+	"apps": {"applications"},
+	"az":   {"availability", "zone"},
+	"azs":  {"availability", "zones"},
+	"lb":   {"load", "balancer"},
+	"auth": {"authentication"},
+	"cert": {"certificate"},
+	"max":  {"maximum"},
+	"min":  {"minimum"},
+	"num":  {"number"},
+	"ha":   {"high", "availability"},
+	"mgmt": {"management"},
+	"env":  {"environment"},
+	"sys":  {"system"},
+}
+
+// tokenizeProperty returns the terms of a property name, without the .properties. prefix
 func tokenizeProperty(property string) []string {
-	// Remove common prefixes
-	prop := strings.TrimPrefix(property, ".properties.")
-	prop = strings.TrimPrefix(prop, ".cloud_controller.")
+	return terms(bareProperty(property))
+}
 
-	// Split on underscores and dots
-	parts := strings.FieldsFunc(prop, func(r rune) bool {
-		return r == '_' || r == '.' || r == '-'
+// terms splits text into lowercase stemmed words, dropping stopwords and expanding abbreviations
+func terms(text string) []string {
+	var result []string
+	analyze(text, func(term, _ string) {
+		result = append(result, term)
+	})
+	return result
+}
+
+// analyze calls visit with each term in text and the word it came from
+func analyze(text string, visit func(term, word string)) {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
 	})
 
-	// Filter stopwords
-	var tokens []string
-	for _, part := range parts {
-		lower := strings.ToLower(part)
-		if !stopwords[lower] && len(lower) > 2 {
-			tokens = append(tokens, lower)
+	for _, word := range words {
+		if stopwords[word] || len(word) < 2 {
+			continue
+		}
+		visit(stem(word), word)
+		for _, expansion := range abbreviations[word] {
+			visit(stem(expansion), expansion)
 		}
 	}
+}
 
-	return tokens
+// stem strips common English suffixes so "limits", "limiting", and "limited" compare equal. A trailing
+// "e" is dropped whether or not a suffix was, so "update", "updates", and "updated" also compare equal.
+func stem(word string) string {
+	for _, suffix := range []string{"ing", "ed", "s"} {
+		if len(word) <= len(suffix)+2 || !strings.HasSuffix(word, suffix) || strings.HasSuffix(word, "ss") {
+			continue
+		}
+		word = strings.TrimSuffix(word, suffix)
+		// logging -> logg -> log
+		if suffix != "s" && len(word) > 2 && word[len(word)-1] == word[len(word)-2] && !strings.ContainsRune("lsz", rune(word[len(word)-1])) {
+			word = word[:len(word)-1]
+		}
+		break
+	}
+	if len(word) > 3 {
+		word = strings.TrimSuffix(word, "e")
+	}
+	return word
 }
 
-func keywordScore(tokens []string, feature Feature) float64 {
-	if len(tokens) == 0 {
-		return 0.0
+// normalize scales a vector to unit length
+func normalize(vector map[string]float64) map[string]float64 {
+	var sum float64
+	for _, v := range vector {
+		sum += v * v
+	}
+	if sum == 0 {
+		return vector
 	}
+	length := math.Sqrt(sum)
+	for term := range vector {
+		vector[term] /= length
+	}
+	return vector
+}
 
-	searchText := strings.ToLower(feature.Title + " " + feature.Description)
-	matched := 0
+// cosine returns the similarity of two unit vectors and each shared term's contribution to it
+func cosine(a, b map[string]float64) (float64, map[string]float64) {
+	var score float64
+	shared := make(map[string]float64)
+	for term, weight := range a {
+		if other, ok := b[term]; ok {
+			shared[term] = weight * other
+			score += weight * other
+		}
+	}
+	return score, shared
+}
 
-	for _, token := range tokens {
-		if strings.Contains(searchText, token) {
-			matched++
+// sharesAny reports whether any shared term is one of the given terms
+func sharesAny(shared map[string]float64, wanted map[string]bool) bool {
+	for term := range shared {
+		if wanted[term] {
+			return true
 		}
 	}
+	return false
+}
+
+// explain lists the shared terms, most significant first, separating those found only in the form context
+func (m *Matcher) explain(score float64, shared map[string]float64, isNameTerm map[string]bool) string {
+	ordered := make([]string, 0, len(shared))
+	for term := range shared {
+		ordered = append(ordered, term)
+	}
+	sort.Slice(ordered, func(i, j int) bool {
+		if shared[ordered[i]] != shared[ordered[j]] {
+			return shared[ordered[i]] > shared[ordered[j]]
+		}
+		return ordered[i] < ordered[j]
+	})
+
+	var fromName, fromContext []string
+	for _, term := range ordered {
+		if isNameTerm[term] {
+			fromName = append(fromName, m.surface[term])
+		} else {
+			fromContext = append(fromContext, m.surface[term])
+		}
+	}
+
+	explanation := fmt.Sprintf("score %.2f: name terms %s", score, strings.Join(fromName, ", "))
+	if len(fromContext) > 0 {
+		explanation += fmt.Sprintf("; form terms %s", strings.Join(fromContext, ", "))
+	}
+	return explanation
+}
+
+// bareProperty strips the .properties. prefix from a property reference
+func bareProperty(property string) string {
+	return strings.TrimPrefix(strings.TrimPrefix(property, ".properties."), ".")
+}
+
+// mentionsProperty reports whether a feature names a multi-word property as a whole identifier,
+// so app_log_rate_limiting does not match text mentioning app_log_rate_limiting_burst
+func mentionsProperty(feature Feature, property string) bool {
+	name := strings.ToLower(bareProperty(property))
+	if !strings.ContainsAny(name, "_.-") {
+		return false
+	}
+
+	text := strings.ToLower(feature.Title + " " + feature.Description)
+	for offset := 0; ; {
+		i := strings.Index(text[offset:], name)
+		if i < 0 {
+			return false
+		}
+		start, end := offset+i, offset+i+len(name)
+		if (start == 0 || !isIdentifierByte(text[start-1])) && (end == len(text) || !isIdentifierByte(text[end])) {
+			return true
+		}
+		offset = start + 1
+	}
+}
 
-	// Score: (matched / total) * 0.9
-	return (float64(matched) / float64(len(tokens))) * 0.9
+// isIdentifierByte reports whether b can be part of a property name
+func isIdentifierByte(b byte) bool {
+	return b == '_' || b >= 'a' && b <= 'z' || b >= '0' && b <= '9'
 }
@@ -1,6 +1,7 @@
 package releasenotes
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -65,9 +66,48 @@ func TestKeywordMatch(t *testing.T) {
 		t.Errorf("Expected keyword match, got %s", match.MatchType)
 	}
 
-	expectedConfidence := 0.9
-	if match.Confidence != expectedConfidence {
-		t.Errorf("Expected confidence %f, got %f", expectedConfidence, match.Confidence)
+	if match.Confidence < MatchThreshold || match.Confidence >= 1.0 {
+		t.Errorf("Expected keyword confidence in [%.2f, 1.0), got %f", MatchThreshold, match.Confidence)
+	}
+
+	if match.Feature.Title != "Application Log Rate Limiting" {
+		t.Errorf("Expected Application Log Rate Limiting, got %q", match.Feature.Title)
+	}
+	if !strings.Contains(match.Explanation, "name terms") {
+		t.Errorf("Expected explanation to list name terms, got %q", match.Explanation)
+	}
+	for _, term := range []string{"log", "rate", "limiting"} {
+		if !strings.Contains(match.Explanation, term) {
+			t.Errorf("Expected explanation to mention %q, got %q", term, match.Explanation)
+		}
+	}
+}
+
+func TestStem(t *testing.T) {
+	tests := map[string]string{
+		"limits":   "limit",
+		"limiting": "limit",
+		"limited":  "limit",
+		"logging":  "log",
+		"access":   "access",
+	}
+	for word, expected := range tests {
+		if got := stem(word); got != expected {
+			t.Errorf("stem(%q) = %q, expected %q", word, got, expected)
+		}
+	}
+
+	// Singular, plural and past forms stem to the same token
+	for _, forms := range [][]string{
+		{"rate", "rates"},
+		{"update", "updates", "updated"},
+		{"state", "states"},
+	} {
+		for _, form := range forms[1:] {
+			if stem(form) != stem(forms[0]) {
+				t.Errorf("Expected stem(%q) = stem(%q), got %q and %q", form, forms[0], stem(form), stem(forms[0]))
+			}
+		}
 	}
 }
 
@@ -78,7 +118,7 @@ func TestTokenizeProperty(t *testing.T) {
 	}{
 		{
 			property: ".properties.app_log_rate_limiting",
-			expected: []string{"app", "log", "rate", "limiting"},
+			expected: []string{"app", "application", "log", "rat", "limit"},
 		},
 		{
 			property: ".properties.security_scanner_enabled",
@@ -100,3 +140,81 @@ func TestTokenizeProperty(t *testing.T) {
 		})
 	}
 }
+
+func TestDirectMatch_WholeIdentifier(t *testing.T) {
+	features := []Feature{
+		{Title: "Burst Limits", Description: "Adds app_log_rate_limiting_burst to allow short bursts."},
+	}
+
+	matches := NewMatcher(features).Match([]string{"app_log_rate_limiting"})
+	if match, ok := matches["app_log_rate_limiting"]; ok && match.MatchType == "direct" {
+		t.Errorf("Expected no direct match on a longer identifier, got %+v", match)
+	}
+}
+
+func TestMatcher_ContextAndAbbreviations(t *testing.T) {
+	features := []Feature{
+		{Title: "Cloud Controller Database Encryption", Description: "Rotate the database encryption key used by the Cloud Controller."},
+		{Title: "CredHub Encryption Providers", Description: "CredHub can use an HSM as its encryption provider."},
+		{Title: "Gorouter Keepalive Timeout", Description: "Sets an idle timeout for keepalive connections."},
+	}
+
+	matcher := NewMatcher(features).WithContext(map[string]string{
+		"cc_db_key_rotation": "Cloud Controller Rotate the encryption key",
+	})
+
+	// cc and db expand to the Cloud Controller feature's words; CredHub shares only "encryption" from the form label
+	candidates := matcher.Candidates("cc_db_key_rotation", DefaultCandidates)
+	if len(candidates) != 1 || candidates[0].Feature.Title != "Cloud Controller Database Encryption" {
+		t.Fatalf("Expected only the Cloud Controller feature, got %+v", candidates)
+	}
+	if !strings.Contains(candidates[0].Explanation, "name terms") || !strings.Contains(candidates[0].Explanation, "form terms") {
+		t.Errorf("Expected explanation to separate name and form terms, got %q", candidates[0].Explanation)
+	}
+
+	match := matcher.Match([]string{"cc_db_key_rotation"})["cc_db_key_rotation"]
+	if match.Feature.Title != "Cloud Controller Database Encryption" || len(match.Candidates) != 1 {
+		t.Errorf("Expected match with candidates, got %+v", match)
+	}
+}
+
+func TestMatcher_ContextAloneDoesNotMatch(t *testing.T) {
+	features := []Feature{
+		{Title: "Cloud Controller Database Encryption", Description: "Rotate the database encryption key used by the Cloud Controller."},
+	}
+
+	matcher := NewMatcher(features).WithContext(map[string]string{
+		"blobstore_type": "Cloud Controller database encryption blobstore type",
+	})
+	if candidates := matcher.Candidates("blobstore_type", DefaultCandidates); len(candidates) != 0 {
+		t.Errorf("Expected no candidates without a shared name term, got %+v", candidates)
+	}
+}
+
+func TestMatchPreferring(t *testing.T) {
+	features := []Feature{
+		{Title: "New Features", Description: "Adds legacy_syslog_drain for forwarding to older servers.", Version: "6.0.1", Kind: SectionFeatures},
+		{Title: "Deprecations", Description: "The legacy_syslog_drain property is deprecated.", Version: "10.2.4", Kind: SectionDeprecations},
+		{Title: "Syslog Forwarding", Description: "Forward platform logs to a syslog drain over TLS.", Version: "10.2.4", Kind: SectionFeatures},
+		{Title: "Breaking Changes", Description: "Plain TCP syslog drain forwarding was removed.", Version: "10.2.5", Kind: SectionBreakingChanges},
+	}
+	matcher := NewMatcher(features)
+
+	// Both notes name the property; a removal is credited to the deprecation
+	if match := matcher.Match([]string{"legacy_syslog_drain"})["legacy_syslog_drain"]; match.Feature.Title != "New Features" {
+		t.Errorf("Expected first mention without a preference, got %q", match.Feature.Title)
+	}
+	preferred := matcher.MatchPreferring([]string{"legacy_syslog_drain"}, SectionBreakingChanges, SectionDeprecations)
+	if match := preferred["legacy_syslog_drain"]; match.Feature.Title != "Deprecations" {
+		t.Errorf("Expected deprecation notice when preferred, got %q", match.Feature.Title)
+	}
+
+	// Keyword scores in preferred sections are boosted and explained
+	candidates := matcher.CandidatesPreferring("syslog_drain_tcp_forwarding", DefaultCandidates, SectionBreakingChanges)
+	if len(candidates) == 0 || candidates[0].Feature.Title != "Breaking Changes" {
+		t.Fatalf("Expected breaking change to rank first, got %+v", candidates)
+	}
+	if !strings.Contains(candidates[0].Explanation, "boosted for breaking-changes section") {
+		t.Errorf("Expected boost in explanation, got %q", candidates[0].Explanation)
+	}
+}
@@ -1,9 +1,10 @@
 // ABOUTME: HTML parser for extracting features from release notes.
-// ABOUTME: Identifies feature sections and descriptions for matching.
+// ABOUTME: Builds a release -> section -> item tree and flattens it into features for matching.
 package releasenotes
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 
 	"golang.org/x/net/html"
@@ -14,62 +15,246 @@ type Feature struct {
 	Title       string
 	Description string
 	Position    int
+	Version     string      // Release the feature shipped in, when known
+	Kind        SectionKind // Kind of section the feature was listed under
 }
 
-// ParseHTML extracts features from release notes HTML
+// releaseHeadingPattern matches headings that name a release, such as "10.2.5" or "v10.2.5+LTS-T"
+var releaseHeadingPattern = regexp.MustCompile(`^(?:v|Version\s+)?(\d+\.\d+\.\d+\S*)`)
+
+// ParseHTML extracts features from release notes HTML, one per list item or block of prose
 func ParseHTML(htmlContent string) ([]Feature, error) {
+	notes, err := ParseReleaseNotes(htmlContent)
+	if err != nil {
+		return nil, err
+	}
+	return notes.Features(), nil
+}
+
+// ParseReleaseNotes parses release notes HTML into releases, their sections, and the items in each section.
+// Headings naming a version start a release; other headings start a section, and headings nested
+// deeper than the current section start a titled item.
+func ParseReleaseNotes(htmlContent string) (*ReleaseNotes, error) {
+	return ParseReleaseNotesWithProfile(htmlContent, nil)
+}
+
+// ParseReleaseNotesWithProfile parses release notes like ParseReleaseNotes, using a product's heading
+// levels and section names where the profile sets them
+func ParseReleaseNotesWithProfile(htmlContent string, profile *ParserProfile) (*ReleaseNotes, error) {
 	doc, err := html.Parse(strings.NewReader(htmlContent))
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse HTML: %w", err)
 	}
 
-	var features []Feature
-	position := 0
+	p := &notesParser{notes: &ReleaseNotes{}}
+	if profile != nil {
+		p.profile = *profile
+	}
+	p.walk(doc)
+	return p.notes, nil
+}
+
+// notesParser tracks where in the release -> section -> item tree the next element belongs
+type notesParser struct {
+	notes        *ReleaseNotes
+	profile      ParserProfile
+	sectionLevel int
+	proseOpen    bool // following paragraphs extend the last item
+}
 
-	var extractFeatures func(*html.Node)
-	var currentFeature *Feature
+func (p *notesParser) walk(n *html.Node) {
+	if n.Type == html.ElementNode {
+		switch n.Data {
+		case "head", "script", "style", "nav", "h1":
+			return
+		case "table":
+			p.table(n)
+			p.proseOpen = false
+			return
+		case "h2", "h3", "h4", "h5", "h6":
+			p.heading(int(n.Data[1]-'0'), extractText(n))
+			return
+		case "li":
+			p.addItem("", extractText(n))
+			p.proseOpen = false
+			return
+		case "p":
+			p.paragraph(extractText(n))
+			return
+		case "ul", "ol":
+			p.proseOpen = false
+		}
+	}
 
-	extractFeatures = func(n *html.Node) {
-		if n.Type == html.ElementNode {
-			switch n.Data {
-			case "h2":
-				// Save previous feature
-				if currentFeature != nil {
-					features = append(features, *currentFeature)
-				}
-				// Start new feature
-				position++
-				currentFeature = &Feature{
-					Title:    extractText(n),
-					Position: position,
-				}
-			case "p", "ul", "li":
-				// Add to current feature description
-				if currentFeature != nil {
-					text := extractText(n)
-					if text != "" {
-						if currentFeature.Description != "" {
-							currentFeature.Description += " "
-						}
-						currentFeature.Description += text
-					}
-				}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		p.walk(c)
+	}
+}
+
+func (p *notesParser) heading(level int, text string) {
+	if text == "" {
+		return
+	}
+	p.proseOpen = false
+
+	if match := releaseHeadingPattern.FindStringSubmatch(text); match != nil &&
+		(p.profile.ReleaseHeadingLevel == 0 || level == p.profile.ReleaseHeadingLevel) {
+		p.notes.Releases = append(p.notes.Releases, Release{Version: match[1], Title: text})
+		p.sectionLevel = 0
+		return
+	}
+
+	startsSection := p.sectionLevel == 0 || level <= p.sectionLevel
+	if p.profile.SectionHeadingLevel != 0 {
+		startsSection = level <= p.profile.SectionHeadingLevel
+	}
+	if startsSection {
+		release := p.currentRelease()
+		release.Sections = append(release.Sections, Section{Title: text, Kind: p.classify(text)})
+		p.sectionLevel = level
+		return
+	}
+
+	p.addItem(text, "")
+	p.proseOpen = true
+}
+
+// classify determines a section's kind, trying the profile's section names before the default keywords
+func (p *notesParser) classify(title string) SectionKind {
+	lower := strings.ToLower(title)
+	for _, entry := range sectionKeywords {
+		for _, name := range p.profile.Sections[entry.kind] {
+			if strings.Contains(lower, strings.ToLower(name)) {
+				return entry.kind
 			}
 		}
+	}
+	return ClassifySection(title)
+}
 
+// table records a component version table on the current release; other tables are ignored.
+// A table qualifies when its header names a component and a version column, or when it has no
+// recognizable header but sits in a components section, in which case the first two columns are used.
+func (p *notesParser) table(n *html.Node) {
+	rows := tableRows(n)
+	if len(rows) == 0 {
+		return
+	}
+
+	nameCol, versionCol := -1, -1
+	for i, cell := range rows[0] {
+		lower := strings.ToLower(cell)
+		switch {
+		case versionCol < 0 && strings.Contains(lower, "version"):
+			versionCol = i
+		case nameCol < 0 && componentHeaderPattern.MatchString(lower):
+			nameCol = i
+		}
+	}
+
+	if nameCol >= 0 && versionCol >= 0 {
+		rows = rows[1:]
+	} else if p.inSection(SectionComponents) {
+		nameCol, versionCol = 0, 1
+		if versionCol < len(rows[0]) && strings.Contains(strings.ToLower(rows[0][versionCol]), "version") {
+			rows = rows[1:]
+		}
+	} else {
+		return
+	}
+
+	release := p.currentRelease()
+	for _, row := range rows {
+		if nameCol >= len(row) || versionCol >= len(row) {
+			continue
+		}
+		fields := strings.Fields(row[versionCol])
+		if row[nameCol] == "" || len(fields) == 0 {
+			continue
+		}
+		// Versions are often footnoted, e.g. "0.301.0*"
+		version := strings.TrimRight(fields[0], "*†‡")
+		release.Components = append(release.Components, Component{Name: row[nameCol], Version: version})
+	}
+}
+
+// componentHeaderPattern matches table headings for the component name column
+var componentHeaderPattern = regexp.MustCompile(`\b(component|release|name|package)s?\b`)
+
+// tableRows returns the text of each cell, row by row
+func tableRows(n *html.Node) [][]string {
+	var rows [][]string
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode && n.Data == "tr" {
+			var row []string
+			for c := n.FirstChild; c != nil; c = c.NextSibling {
+				if c.Type == html.ElementNode && (c.Data == "td" || c.Data == "th") {
+					row = append(row, extractText(c))
+				}
+			}
+			rows = append(rows, row)
+			return
+		}
 		for c := n.FirstChild; c != nil; c = c.NextSibling {
-			extractFeatures(c)
+			walk(c)
 		}
 	}
+	walk(n)
+	return rows
+}
+
+func (p *notesParser) paragraph(text string) {
+	if text == "" {
+		return
+	}
+
+	if p.proseOpen {
+		items := p.currentSection().Items
+		last := &items[len(items)-1]
+		if last.Text != "" {
+			last.Text += " "
+		}
+		last.Text += text
+		return
+	}
 
-	extractFeatures(doc)
+	p.addItem("", text)
+	p.proseOpen = true
+}
 
-	// Don't forget last feature
-	if currentFeature != nil {
-		features = append(features, *currentFeature)
+func (p *notesParser) addItem(title, text string) {
+	if title == "" && text == "" {
+		return
 	}
+	section := p.currentSection()
+	section.Items = append(section.Items, Item{Title: title, Text: text})
+}
 
-	return features, nil
+// currentRelease returns the release being filled, starting an unversioned one if needed
+func (p *notesParser) currentRelease() *Release {
+	if len(p.notes.Releases) == 0 {
+		p.notes.Releases = append(p.notes.Releases, Release{})
+	}
+	return &p.notes.Releases[len(p.notes.Releases)-1]
+}
+
+// inSection reports whether the most recent section is of the given kind, without starting one
+func (p *notesParser) inSection(kind SectionKind) bool {
+	if len(p.notes.Releases) == 0 {
+		return false
+	}
+	sections := p.notes.Releases[len(p.notes.Releases)-1].Sections
+	return len(sections) > 0 && sections[len(sections)-1].Kind == kind
+}
+
+// currentSection returns the section being filled, starting one named after the release if needed
+func (p *notesParser) currentSection() *Section {
+	release := p.currentRelease()
+	if len(release.Sections) == 0 {
+		release.Sections = append(release.Sections, Section{Title: release.Title, Kind: SectionOther})
+	}
+	return &release.Sections[len(release.Sections)-1]
 }
 
 func extractText(n *html.Node) string {
@@ -44,3 +44,64 @@ func TestExtractFeatures_PropertyMentions(t *testing.T) {
 func containsString(s, substr string) bool {
 	return strings.Contains(s, substr)
 }
+
+func TestParseHTML_ReleaseHeadings(t *testing.T) {
+	html := `<html><body>
+<h2>Overview</h2><p>Intro text.</p>
+<h2>v10.2.5+LTS-T</h2><p>Patch notes.</p>
+<h2>New Features</h2><p>Adds foo_enabled.</p>
+<h2>10.2.4</h2><p>Older patch.</p>
+</body></html>`
+
+	features, err := ParseHTML(html)
+	if err != nil {
+		t.Fatalf("ParseHTML failed: %v", err)
+	}
+
+	expected := []string{"", "10.2.5+LTS-T", "10.2.5+LTS-T", "10.2.4"}
+	if len(features) != len(expected) {
+		t.Fatalf("Expected %d features, got %d", len(expected), len(features))
+	}
+	for i, feature := range features {
+		if feature.Version != expected[i] {
+			t.Errorf("Feature %q: expected version %q, got %q", feature.Title, expected[i], feature.Version)
+		}
+	}
+}
+
+func TestParseReleaseNotesWithProfile(t *testing.T) {
+	html := `<html><body>
+<h2>10.2.5</h2>
+<h3>Upgrade Considerations</h3>
+<h4>Syslog drains</h4><p>Plain TCP drains are no longer supported.</p>
+<h3>Highlights</h3>
+<h4>Version 1.2.3 of the log cache</h4><p>Faster queries.</p>
+</body></html>`
+
+	profile := &ParserProfile{
+		ReleaseHeadingLevel: 2,
+		SectionHeadingLevel: 3,
+		Sections:            map[SectionKind][]string{SectionBreakingChanges: {"upgrade considerations"}, SectionFeatures: {"Highlights"}},
+	}
+	notes, err := ParseReleaseNotesWithProfile(html, profile)
+	if err != nil {
+		t.Fatalf("ParseReleaseNotesWithProfile failed: %v", err)
+	}
+
+	if len(notes.Releases) != 1 {
+		t.Fatalf("Expected only the h2 to start a release, got %d releases", len(notes.Releases))
+	}
+	sections := notes.Releases[0].Sections
+	if len(sections) != 2 || sections[0].Kind != SectionBreakingChanges || sections[1].Kind != SectionFeatures {
+		t.Fatalf("Expected breaking changes and features sections, got %+v", sections)
+	}
+	if items := sections[1].Items; len(items) != 1 || items[0].Title != "Version 1.2.3 of the log cache" {
+		t.Errorf("Expected the h4 version heading to be an item, got %+v", items)
+	}
+
+	// Without the profile the h4 version heading starts a release
+	notes, _ = ParseReleaseNotes(html)
+	if len(notes.Releases) != 2 {
+		t.Errorf("Expected 2 releases without a profile, got %d", len(notes.Releases))
+	}
+}
@@ -0,0 +1,183 @@
+// ABOUTME: Aggregates release notes across every release between two tile versions.
+// ABOUTME: Resolves the documentation pages covering the range and tags features with their release.
+package releasenotes
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/malston/tile-diff/pkg/version"
+)
+
+// Page identifies a release notes page and the version line (e.g. 10.2) it documents
+type Page struct {
+	URL     string
+	Line    string
+	Profile *ParserProfile // How to parse the page; nil for the defaults
+}
+
+// VersionLine returns the major.minor line of a version (10.2.5+LTS-T -> 10.2), or the version unchanged if it cannot be parsed
+func VersionLine(v string) string {
+	parsed, err := version.Parse(v)
+	if err != nil || len(parsed.Segments) < 2 {
+		return v
+	}
+	return fmt.Sprintf("%d.%d", parsed.Major(), parsed.Minor())
+}
+
+// InRange reports whether v is newer than oldVersion and no newer than newVersion
+func InRange(v, oldVersion, newVersion string) bool {
+	afterOld, err := version.Compare(v, oldVersion)
+	if err != nil {
+		return false
+	}
+	upToNew, err := version.Compare(v, newVersion)
+	if err != nil {
+		return false
+	}
+	return afterOld > 0 && upToNew <= 0
+}
+
+// VersionsBetween returns the available versions in (oldVersion, newVersion], oldest first
+func VersionsBetween(available []string, oldVersion, newVersion string) []string {
+	var between []string
+	for _, v := range available {
+		if InRange(v, oldVersion, newVersion) {
+			between = append(between, v)
+		}
+	}
+
+	sort.SliceStable(between, func(i, j int) bool {
+		return versionLess(between[i], between[j])
+	})
+	return between
+}
+
+// SkippedLines describes the version lines between oldVersion's and newVersion's that PagesForRange cannot
+// cover without a list of available releases (6.0.22 -> 10.2.5 gives 6.1+, 7.x, 8.x, 9.x, 10.0, 10.1)
+func SkippedLines(oldVersion, newVersion string) []string {
+	oldParsed, err := version.Parse(oldVersion)
+	if err != nil || len(oldParsed.Segments) < 2 {
+		return nil
+	}
+	newParsed, err := version.Parse(newVersion)
+	if err != nil || len(newParsed.Segments) < 2 || oldParsed.Compare(newParsed) >= 0 {
+		return nil
+	}
+
+	var lines []string
+	firstMinor := 0
+	if oldParsed.Major() == newParsed.Major() {
+		firstMinor = oldParsed.Minor() + 1
+	} else {
+		lines = append(lines, fmt.Sprintf("%d.%d+", oldParsed.Major(), oldParsed.Minor()+1))
+		for major := oldParsed.Major() + 1; major < newParsed.Major(); major++ {
+			lines = append(lines, fmt.Sprintf("%d.x", major))
+		}
+	}
+	for minor := firstMinor; minor < newParsed.Minor(); minor++ {
+		lines = append(lines, fmt.Sprintf("%d.%d", newParsed.Major(), minor))
+	}
+	return lines
+}
+
+// versionLess orders versions oldest first, placing versions that cannot be parsed after all others
+// in their original order
+func versionLess(a, b string) bool {
+	parsedA, errA := version.Parse(a)
+	parsedB, errB := version.Parse(b)
+	if errA != nil || errB != nil {
+		return errA == nil && errB != nil
+	}
+	return parsedA.Compare(parsedB) < 0
+}
+
+// PagesForRange resolves the release notes pages of a registered product covering an upgrade
+func (r *Registry) PagesForRange(productID string, available []string, oldVersion, newVersion string) ([]Page, error) {
+	product, ok := r.Product(productID)
+	if !ok {
+		return nil, fmt.Errorf("product %s not found in config", productID)
+	}
+	return product.PagesForRange(available, oldVersion, newVersion), nil
+}
+
+// PagesForRange resolves the release notes pages covering every release after oldVersion up to newVersion.
+// With a list of available releases (e.g. from Pivnet) each version line containing a release in range is
+// included; without one, or if none fall in range, the new version line is used. The old version's own
+// line is always included so its entry can serve as the baseline for component comparisons.
+func (p *Product) PagesForRange(available []string, oldVersion, newVersion string) []Page {
+	releases := append([]string{oldVersion}, VersionsBetween(available, oldVersion, newVersion)...)
+	if len(releases) == 1 {
+		releases = append(releases, newVersion)
+	}
+
+	var profile *ParserProfile
+	if !p.Parser.IsZero() {
+		profile = &p.Parser
+	}
+
+	var pages []Page
+	seenURLs := make(map[string]bool)
+	for _, release := range releases {
+		if release == "" {
+			continue
+		}
+		url := p.ResolveURL(release)
+		if seenURLs[url] {
+			continue
+		}
+		seenURLs[url] = true
+		pages = append(pages, Page{URL: url, Line: VersionLine(release), Profile: profile})
+	}
+
+	return pages
+}
+
+// FetchReleaseNotes fetches and parses each page, tags releases without a version heading with the page's
+// version line, and keeps only releases after oldVersion up to newVersion, oldest first. The old version's
+// own entry, if a page lists it, is kept as the baseline for component comparisons.
+// Pages that fail to load are skipped; an error is returned only if none could be read.
+func FetchReleaseNotes(fetcher *Fetcher, pages []Page, oldVersion, newVersion string) (*ReleaseNotes, error) {
+	notes := &ReleaseNotes{}
+	var lastErr error
+	loaded := 0
+
+	for _, page := range pages {
+		html, err := fetcher.Fetch(page.URL)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		parsed, err := ParseReleaseNotesWithProfile(html, page.Profile)
+		if err != nil {
+			lastErr = fmt.Errorf("failed to parse %s: %w", page.URL, err)
+			continue
+		}
+		loaded++
+
+		for _, release := range parsed.Releases {
+			if release.Version == "" {
+				release.Version = page.Line
+			} else if oldVersion != "" && newVersion != "" && !InRange(release.Version, oldVersion, newVersion) {
+				if cmp, err := version.Compare(release.Version, oldVersion); err == nil && cmp == 0 {
+					baseline := release
+					notes.Baseline = &baseline
+				}
+				continue
+			}
+			notes.Releases = append(notes.Releases, release)
+		}
+	}
+
+	if loaded == 0 && lastErr != nil {
+		return nil, lastErr
+	}
+
+	// Oldest first, so the matcher attributes a property to the release that introduced it
+	sort.SliceStable(notes.Releases, func(i, j int) bool {
+		return versionLess(notes.Releases[i].Version, notes.Releases[j].Version)
+	})
+
+	return notes, nil
+}
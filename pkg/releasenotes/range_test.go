@@ -0,0 +1,231 @@
+// ABOUTME: Unit tests for aggregating release notes across a version range.
+// ABOUTME: Serves per-line release note pages from httptest and checks tagging, filtering, and attribution.
+package releasenotes
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestVersionLine(t *testing.T) {
+	tests := map[string]string{
+		"10.2.5+LTS-T":   "10.2",
+		"6.0.22-build.2": "6.0",
+		"10-2":           "10-2",
+	}
+	for input, expected := range tests {
+		if got := VersionLine(input); got != expected {
+			t.Errorf("VersionLine(%q) = %q, expected %q", input, got, expected)
+		}
+	}
+}
+
+func TestInRange(t *testing.T) {
+	tests := []struct {
+		version  string
+		expected bool
+	}{
+		{"6.0.22", false},
+		{"6.0.23", true},
+		{"10.2.5", true},
+		{"10.2.6", false},
+		{"not-a-version", false},
+	}
+	for _, tt := range tests {
+		if got := InRange(tt.version, "6.0.22", "10.2.5"); got != tt.expected {
+			t.Errorf("InRange(%s) = %v, expected %v", tt.version, got, tt.expected)
+		}
+	}
+}
+
+func TestVersionsBetween(t *testing.T) {
+	available := []string{"10.2.6", "10.2.5", "6.0.22", "10.0.0", "6.0.23", "6.0.21"}
+
+	got := VersionsBetween(available, "6.0.22", "10.2.5")
+
+	expected := []string{"6.0.23", "10.0.0", "10.2.5"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+}
+
+func TestSkippedLines(t *testing.T) {
+	tests := []struct {
+		oldVersion, newVersion string
+		expected               []string
+	}{
+		{"6.0.22", "10.2.5", []string{"6.1+", "7.x", "8.x", "9.x", "10.0", "10.1"}},
+		{"10.0.3", "10.2.5+LTS-T", []string{"10.1"}},
+		{"10.2.1", "10.2.5", nil},
+		{"10.2.5", "6.0.22", nil},
+		{"unknown", "10.2.5", nil},
+	}
+
+	for _, tt := range tests {
+		if got := SkippedLines(tt.oldVersion, tt.newVersion); !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("SkippedLines(%s, %s) = %v, expected %v", tt.oldVersion, tt.newVersion, got, tt.expected)
+		}
+	}
+}
+
+func TestVersionLess_UnparseableLast(t *testing.T) {
+	versions := []string{"Overview", "10.2.5", "6.0.23", "unreleased", "10.0"}
+
+	sort.SliceStable(versions, func(i, j int) bool { return versionLess(versions[i], versions[j]) })
+
+	expected := []string{"6.0.23", "10.0", "10.2.5", "Overview", "unreleased"}
+	if !reflect.DeepEqual(versions, expected) {
+		t.Errorf("Expected %v, got %v", expected, versions)
+	}
+}
+
+func TestPagesForRange(t *testing.T) {
+	registry := &Registry{Products: []Product{{ID: "cf", ReleaseNotes: "https://docs.example.com/cf/{version}/release-notes.html"}}}
+	available := []string{"6.0.21", "6.0.22", "6.0.23", "10.0.0", "10.2.4", "10.2.5", "10.2.6"}
+
+	pages, err := registry.PagesForRange("cf", available, "6.0.22", "10.2.5")
+	if err != nil {
+		t.Fatalf("PagesForRange failed: %v", err)
+	}
+
+	expected := []Page{
+		{URL: "https://docs.example.com/cf/6-0/release-notes.html", Line: "6.0"},
+		{URL: "https://docs.example.com/cf/10-0/release-notes.html", Line: "10.0"},
+		{URL: "https://docs.example.com/cf/10-2/release-notes.html", Line: "10.2"},
+	}
+	if !reflect.DeepEqual(pages, expected) {
+		t.Errorf("Expected %v, got %v", expected, pages)
+	}
+}
+
+func TestPagesForRange_OldVersionIsLastOfItsLine(t *testing.T) {
+	product := &Product{ID: "cf", ReleaseNotes: "https://docs.example.com/cf/{version}/release-notes.html"}
+	available := []string{"6.0.21", "6.0.22", "10.0.0", "10.2.5"}
+
+	pages := product.PagesForRange(available, "6.0.22", "10.2.5")
+
+	expected := []Page{
+		{URL: "https://docs.example.com/cf/6-0/release-notes.html", Line: "6.0"},
+		{URL: "https://docs.example.com/cf/10-0/release-notes.html", Line: "10.0"},
+		{URL: "https://docs.example.com/cf/10-2/release-notes.html", Line: "10.2"},
+	}
+	if !reflect.DeepEqual(pages, expected) {
+		t.Errorf("Expected the 6.0 page for the baseline, got %v", pages)
+	}
+}
+
+func TestPagesForRange_FallsBackToVersionLines(t *testing.T) {
+	registry := &Registry{Products: []Product{{ID: "cf", ReleaseNotes: "https://docs.example.com/cf/{version}/release-notes.html"}}}
+
+	pages, err := registry.PagesForRange("cf", nil, "6.0.22", "10.2.5")
+	if err != nil {
+		t.Fatalf("PagesForRange failed: %v", err)
+	}
+
+	if len(pages) != 2 || pages[0].Line != "6.0" || pages[1].Line != "10.2" {
+		t.Errorf("Expected old and new version lines, got %v", pages)
+	}
+}
+
+func TestPagesForRange_UnknownProduct(t *testing.T) {
+	if _, err := (&Registry{}).PagesForRange("unknown", nil, "1.0.0", "2.0.0"); err == nil {
+		t.Error("Expected error for unknown product")
+	}
+}
+
+// serveReleaseNotes serves one release notes page per version line
+func serveReleaseNotes(t *testing.T, pages map[string]string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, ok := pages[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, page)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestFetchReleaseNotes_Range(t *testing.T) {
+	server := serveReleaseNotes(t, map[string]string{
+		"/cf/6-0": `<html><body>
+<h2>6.0.23</h2><p>Adds the router_keepalive_timeout property.</p>
+<h2>6.0.22</h2><p>Adds the already_installed property.</p>
+</body></html>`,
+		"/cf/10-2": `<html><body>
+<h2>10.2.6</h2><p>Adds the future_property property.</p>
+<h2>10.2.5</h2><p>Changes the default of router_keepalive_timeout.</p>
+<h2>Known Issues</h2><p>Nothing new.</p>
+</body></html>`,
+		"/cf/intro": `<html><body><h2>Overview</h2><p>General notes.</p></body></html>`,
+	})
+
+	pages := []Page{
+		{URL: server.URL + "/cf/10-2", Line: "10.2"},
+		{URL: server.URL + "/cf/6-0", Line: "6.0"},
+		{URL: server.URL + "/cf/intro", Line: "10.2"},
+	}
+
+	notes, err := FetchReleaseNotes(NewFetcher(), pages, "6.0.22", "10.2.5")
+	if err != nil {
+		t.Fatalf("FetchReleaseNotes failed: %v", err)
+	}
+	features := notes.Features()
+
+	var got []string
+	for _, feature := range features {
+		got = append(got, feature.Version+" "+feature.Title)
+	}
+	expected := []string{"6.0.23 6.0.23", "10.2 Overview", "10.2.5 10.2.5", "10.2.5 Known Issues"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+
+	// The property is credited to the release that introduced it, not the later one that changed it
+	matches := NewMatcher(features).Match([]string{"router_keepalive_timeout"})
+	if match := matches["router_keepalive_timeout"]; match.Feature.Version != "6.0.23" {
+		t.Errorf("Expected attribution to 6.0.23, got %q", match.Feature.Version)
+	}
+}
+
+func TestFetchReleaseNotes_AllPagesFail(t *testing.T) {
+	server := serveReleaseNotes(t, map[string]string{})
+
+	_, err := FetchReleaseNotes(NewFetcher(), []Page{{URL: server.URL + "/missing", Line: "10.2"}}, "6.0.22", "10.2.5")
+	if err == nil {
+		t.Error("Expected error when no page can be fetched")
+	}
+}
+
+func TestFetchReleaseNotes_Baseline(t *testing.T) {
+	server := serveReleaseNotes(t, map[string]string{
+		"/cf/10-2": `<html><body>
+<h2>10.2.5</h2><table><tr><th>Component</th><th>Version</th></tr><tr><td>gorouter</td><td>0.301.0</td></tr></table>
+<h2>10.2.4</h2><table><tr><th>Component</th><th>Version</th></tr><tr><td>gorouter</td><td>0.300.0</td></tr></table>
+<h2>10.2.3</h2><p>Older release.</p>
+</body></html>`,
+	})
+
+	notes, err := FetchReleaseNotes(NewFetcher(), []Page{{URL: server.URL + "/cf/10-2", Line: "10.2"}}, "10.2.4", "10.2.5")
+	if err != nil {
+		t.Fatalf("FetchReleaseNotes failed: %v", err)
+	}
+
+	if len(notes.Releases) != 1 || notes.Releases[0].Version != "10.2.5" {
+		t.Fatalf("Expected only 10.2.5 in range, got %+v", notes.Releases)
+	}
+	if notes.Baseline == nil || notes.Baseline.Version != "10.2.4" {
+		t.Fatalf("Expected 10.2.4 as baseline, got %+v", notes.Baseline)
+	}
+
+	expected := []ComponentChange{{Name: "gorouter", OldVersion: "0.300.0", NewVersion: "0.301.0"}}
+	if got := notes.ComponentChanges(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, got)
+	}
+}
@@ -1,73 +1,532 @@
-// ABOUTME: Product registry for mapping product IDs to release note URLs.
-// ABOUTME: Loads configuration and resolves versioned URLs for fetching.
+// ABOUTME: Product registry describing each product's aliases, Pivnet slug, release notes and parser profile.
+// ABOUTME: Layers user registries over the built-in one with line-numbered validation and resolves versioned URLs.
 package releasenotes
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
 	"strings"
 
+	"github.com/malston/tile-diff/configs"
+	"github.com/malston/tile-diff/pkg/version"
 	"gopkg.in/yaml.v3"
 )
 
-// ProductConfig maps product IDs to release notes URL patterns
-type ProductConfig map[string]string
+// Registry holds the configured products in file order
+type Registry struct {
+	Products []Product
+}
+
+// Product describes how to find and read a product's tiles and release notes.
+// The yaml tags describe the form printed by Registry.Marshal; files are read by Registry.Overlay.
+type Product struct {
+	ID           string        `yaml:"-"`
+	Name         string        `yaml:"name,omitempty"`
+	Aliases      []string      `yaml:"aliases,omitempty,flow"`
+	PivnetSlug   string        `yaml:"pivnet_slug,omitempty"`
+	ProductType  string        `yaml:"product_type,omitempty"` // Ops Manager product type, used to find the staged product's GUID
+	ProductFile  string        `yaml:"product_file,omitempty"` // Default Pivnet product file name, may be a glob
+	ReleaseNotes string        `yaml:"release_notes"`          // Release notes URL pattern
+	Parser       ParserProfile `yaml:"parser,omitempty"`
+	Sources      []string      `yaml:"-"` // Files that defined or overrode the product, in load order
+}
+
+// ParserProfile adjusts release notes parsing for a product's page layout
+type ParserProfile struct {
+	ReleaseHeadingLevel int                      `yaml:"release_heading_level,omitempty"` // Only headings at this level start a release; 0 for any
+	SectionHeadingLevel int                      `yaml:"section_heading_level,omitempty"` // Headings at or above this level start a section, deeper ones an item; 0 to infer
+	Sections            map[SectionKind][]string `yaml:"sections,omitempty"`              // Extra heading names for each section kind, checked before the defaults
+}
+
+// IsZero reports whether the profile leaves parsing at its defaults
+func (p ParserProfile) IsZero() bool {
+	return p.ReleaseHeadingLevel == 0 && p.SectionHeadingLevel == 0 && len(p.Sections) == 0
+}
+
+// ConfigError is a problem in a product registry file, with the line it was found on
+type ConfigError struct {
+	File string
+	Line int
+	Msg  string
+}
 
-// LoadProductConfig loads product configuration from YAML file
-func LoadProductConfig(path string) (ProductConfig, error) {
+func (e *ConfigError) Error() string {
+	return fmt.Sprintf("%s:%d: %s", e.File, e.Line, e.Msg)
+}
+
+// builtInRegistry names the registry embedded in the binary in errors and printed output
+const builtInRegistry = "built-in configs/products.yaml"
+
+// DefaultRegistry parses the registry built into the binary
+func DefaultRegistry() (*Registry, error) {
+	return ParseRegistry(configs.Products, builtInRegistry)
+}
+
+// UserRegistryPath returns ~/.tile-diff/products.yaml
+func UserRegistryPath() string {
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".tile-diff", "products.yaml")
+}
+
+// LoadEffectiveRegistry builds the registry tile-diff uses: the built-in registry, overlaid by
+// ~/.tile-diff/products.yaml if it exists, then by path if one is given
+func LoadEffectiveRegistry(path string) (*Registry, error) {
+	registry, err := DefaultRegistry()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := os.Stat(UserRegistryPath()); err == nil {
+		if err := registry.OverlayFile(UserRegistryPath()); err != nil {
+			return nil, err
+		}
+	}
+	if path != "" {
+		if err := registry.OverlayFile(path); err != nil {
+			return nil, err
+		}
+	}
+	return registry, nil
+}
+
+// LoadRegistry loads and validates a product registry file
+func LoadRegistry(path string) (*Registry, error) {
+	registry := &Registry{}
+	if err := registry.OverlayFile(path); err != nil {
+		return nil, err
+	}
+	return registry, nil
+}
+
+// ParseRegistry parses a product registry keyed by product ID. Each entry is either a release notes URL
+// pattern or a mapping of product settings. Every problem found is reported with its file and line.
+func ParseRegistry(data []byte, file string) (*Registry, error) {
+	registry := &Registry{}
+	if err := registry.Overlay(data, file); err != nil {
+		return nil, err
+	}
+	return registry, nil
+}
+
+// OverlayFile merges a registry file over the registry
+func (r *Registry) OverlayFile(path string) error {
 	data, err := os.ReadFile(path)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read config: %w", err)
+		return fmt.Errorf("failed to read config: %w", err)
 	}
+	return r.Overlay(data, path)
+}
 
-	var config ProductConfig
-	if err := yaml.Unmarshal(data, &config); err != nil {
-		return nil, fmt.Errorf("failed to parse config: %w", err)
+// Overlay merges a registry file over the registry. New products are added; for products already
+// present, settings given in the file replace the existing ones, aliases are added, and release_notes
+// may be omitted. Nothing is merged unless the whole file is valid.
+func (r *Registry) Overlay(data []byte, file string) error {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("failed to parse config %s: %w", file, err)
+	}
+	if len(doc.Content) == 0 {
+		return nil
 	}
 
-	return config, nil
+	v := &registryValidator{file: file, base: r, names: make(map[string]string)}
+	for _, product := range r.Products {
+		for _, name := range product.names() {
+			if name != "" {
+				v.names[strings.ToLower(name)] = product.ID
+			}
+		}
+	}
+
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		v.errorf(root, "expected a mapping of product IDs to products")
+		return v.err()
+	}
+
+	var products []Product
+	seen := make(map[string]bool)
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, value := root.Content[i], root.Content[i+1]
+		if seen[key.Value] {
+			v.errorf(key, "product %s is defined more than once", key.Value)
+			continue
+		}
+		seen[key.Value] = true
+		if product, ok := v.product(key, value); ok {
+			products = append(products, product)
+		}
+	}
+
+	if err := v.err(); err != nil {
+		return err
+	}
+	for _, product := range products {
+		r.merge(product)
+	}
+	return nil
 }
 
-// ResolveURL resolves a product ID and version to a release notes URL
-func (c ProductConfig) ResolveURL(productID, version string) (string, error) {
-	pattern, ok := c[productID]
+// merge adds a product, or applies the settings it gives to the product with the same ID
+func (r *Registry) merge(overlay Product) {
+	existing, ok := r.Product(overlay.ID)
 	if !ok {
-		return "", fmt.Errorf("product %s not found in config", productID)
+		r.Products = append(r.Products, overlay)
+		return
+	}
+
+	for _, field := range []struct {
+		target *string
+		value  string
+	}{
+		{&existing.Name, overlay.Name},
+		{&existing.PivnetSlug, overlay.PivnetSlug},
+		{&existing.ProductType, overlay.ProductType},
+		{&existing.ProductFile, overlay.ProductFile},
+		{&existing.ReleaseNotes, overlay.ReleaseNotes},
+	} {
+		if field.value != "" {
+			*field.target = field.value
+		}
+	}
+
+	for _, alias := range overlay.Aliases {
+		if !slices.Contains(existing.Aliases, alias) {
+			existing.Aliases = append(existing.Aliases, alias)
+		}
+	}
+
+	if overlay.Parser.ReleaseHeadingLevel != 0 {
+		existing.Parser.ReleaseHeadingLevel = overlay.Parser.ReleaseHeadingLevel
+	}
+	if overlay.Parser.SectionHeadingLevel != 0 {
+		existing.Parser.SectionHeadingLevel = overlay.Parser.SectionHeadingLevel
 	}
+	for kind, names := range overlay.Parser.Sections {
+		if existing.Parser.Sections == nil {
+			existing.Parser.Sections = make(map[SectionKind][]string)
+		}
+		existing.Parser.Sections[kind] = names
+	}
+
+	existing.Sources = append(existing.Sources, overlay.Sources...)
+}
+
+// Marshal renders the registry as products.yaml, noting the files each product came from
+func (r *Registry) Marshal() ([]byte, error) {
+	root := &yaml.Node{Kind: yaml.MappingNode}
+	for _, product := range r.Products {
+		var value yaml.Node
+		if err := value.Encode(product); err != nil {
+			return nil, fmt.Errorf("failed to encode product %s: %w", product.ID, err)
+		}
+		key := &yaml.Node{Kind: yaml.ScalarNode, Value: product.ID}
+		if len(product.Sources) > 0 {
+			key.HeadComment = "From " + strings.Join(product.Sources, ", ")
+		}
+		root.Content = append(root.Content, key, &value)
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{root}}); err != nil {
+		return nil, fmt.Errorf("failed to encode registry: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode registry: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// placeholderPattern matches {placeholder} substitutions in release notes URL patterns
+var placeholderPattern = regexp.MustCompile(`\{[^{}]*\}`)
+
+// urlPlaceholders lists the substitutions ResolveURL understands
+var urlPlaceholders = map[string]bool{
+	"{version}": true, "{full_version}": true, "{line}": true, "{major}": true, "{minor}": true, "{patch}": true,
+}
 
-	url := strings.ReplaceAll(pattern, "{version}", version)
-	return url, nil
+// registryValidator collects errors while decoding registry nodes
+type registryValidator struct {
+	file   string
+	base   *Registry // Registry being overlaid, whose products need not repeat release_notes
+	errors []*ConfigError
+	names  map[string]string // Lowercase ID, alias, slug or product type -> product ID claiming it
 }
 
-var productNameMapping = map[string]string{
-	"tanzu application service": "cf",
-	"tas":                        "cf",
-	"cf":                         "cf",
-	"mysql":                      "p-mysql",
-	"p-mysql":                    "p-mysql",
-	"rabbitmq":                   "p-rabbitmq",
-	"p-rabbitmq":                 "p-rabbitmq",
+func (v *registryValidator) errorf(node *yaml.Node, format string, args ...interface{}) {
+	v.errors = append(v.errors, &ConfigError{File: v.file, Line: node.Line, Msg: fmt.Sprintf(format, args...)})
 }
 
-// IdentifyProduct extracts and normalizes product ID from tile metadata
-func IdentifyProduct(metadata map[string]interface{}) string {
-	// Try "name" field first
-	if name, ok := metadata["name"].(string); ok {
-		normalized := strings.ToLower(strings.TrimSpace(name))
-		if productID, found := productNameMapping[normalized]; found {
-			return productID
+// err joins the errors found, in file order
+func (v *registryValidator) err() error {
+	sort.SliceStable(v.errors, func(i, j int) bool { return v.errors[i].Line < v.errors[j].Line })
+	errs := make([]error, len(v.errors))
+	for i, err := range v.errors {
+		errs[i] = err
+	}
+	return errors.Join(errs...)
+}
+
+// product decodes one registry entry, reporting whether it was valid
+func (v *registryValidator) product(key, value *yaml.Node) (Product, bool) {
+	errorCount := len(v.errors)
+	product := Product{ID: key.Value, Sources: []string{v.file}}
+	if key.Kind != yaml.ScalarNode || key.Value == "" {
+		v.errorf(key, "product ID must be a non-empty string")
+		return product, false
+	}
+
+	var releaseNotes *yaml.Node
+	switch value.Kind {
+	case yaml.ScalarNode:
+		product.ReleaseNotes = value.Value
+		releaseNotes = value
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(value.Content); i += 2 {
+			field, fieldValue := value.Content[i], value.Content[i+1]
+			switch field.Value {
+			case "name":
+				product.Name = v.scalar(product.ID, field, fieldValue)
+			case "aliases":
+				product.Aliases = v.strings(product.ID, field, fieldValue)
+			case "pivnet_slug":
+				product.PivnetSlug = v.scalar(product.ID, field, fieldValue)
+			case "product_type":
+				product.ProductType = v.scalar(product.ID, field, fieldValue)
+			case "release_notes":
+				product.ReleaseNotes = v.scalar(product.ID, field, fieldValue)
+				releaseNotes = fieldValue
+			case "product_file":
+				product.ProductFile = v.scalar(product.ID, field, fieldValue)
+			case "parser":
+				product.Parser = v.parser(product.ID, fieldValue)
+			default:
+				v.errorf(field, "product %s: unknown field %q", product.ID, field.Value)
+			}
+		}
+	default:
+		v.errorf(value, "product %s: expected a release notes URL or a mapping of settings", product.ID)
+		return product, false
+	}
+
+	if _, overridden := v.base.Product(product.ID); !overridden && (releaseNotes == nil || product.ReleaseNotes == "") {
+		v.errorf(key, "product %s: release_notes is required", product.ID)
+	} else if product.ReleaseNotes != "" {
+		for _, placeholder := range placeholderPattern.FindAllString(product.ReleaseNotes, -1) {
+			if !urlPlaceholders[placeholder] {
+				v.errorf(releaseNotes, "product %s: unknown placeholder %s in release_notes", product.ID, placeholder)
+			}
+		}
+	}
+
+	// Every name a product can be identified by must be unambiguous
+	v.claim(product.ID, key, product.ID)
+	if value.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(value.Content); i += 2 {
+			field, fieldValue := value.Content[i], value.Content[i+1]
+			switch field.Value {
+			case "name", "pivnet_slug", "product_type":
+				v.claim(product.ID, fieldValue, fieldValue.Value)
+			case "aliases":
+				for _, alias := range fieldValue.Content {
+					v.claim(product.ID, alias, alias.Value)
+				}
+			}
+		}
+	}
+
+	return product, len(v.errors) == errorCount
+}
+
+// claim records that a name identifies a product, reporting names already used by another product
+func (v *registryValidator) claim(productID string, node *yaml.Node, name string) {
+	lower := strings.ToLower(strings.TrimSpace(name))
+	if lower == "" {
+		return
+	}
+	if owner, ok := v.names[lower]; ok && owner != productID {
+		v.errorf(node, "product %s: %q already identifies product %s", productID, name, owner)
+		return
+	}
+	v.names[lower] = productID
+}
+
+func (v *registryValidator) scalar(productID string, field, value *yaml.Node) string {
+	if value.Kind != yaml.ScalarNode {
+		v.errorf(value, "product %s: %s must be a string", productID, field.Value)
+		return ""
+	}
+	return value.Value
+}
+
+func (v *registryValidator) strings(productID string, field, value *yaml.Node) []string {
+	if value.Kind != yaml.SequenceNode {
+		v.errorf(value, "product %s: %s must be a list of strings", productID, field.Value)
+		return nil
+	}
+	var result []string
+	for _, item := range value.Content {
+		if item.Kind != yaml.ScalarNode {
+			v.errorf(item, "product %s: %s must be a list of strings", productID, field.Value)
+			continue
+		}
+		result = append(result, item.Value)
+	}
+	return result
+}
+
+func (v *registryValidator) parser(productID string, value *yaml.Node) ParserProfile {
+	var profile ParserProfile
+	if value.Kind != yaml.MappingNode {
+		v.errorf(value, "product %s: parser must be a mapping", productID)
+		return profile
+	}
+
+	for i := 0; i+1 < len(value.Content); i += 2 {
+		field, fieldValue := value.Content[i], value.Content[i+1]
+		switch field.Value {
+		case "release_heading_level":
+			profile.ReleaseHeadingLevel = v.headingLevel(productID, field, fieldValue)
+		case "section_heading_level":
+			profile.SectionHeadingLevel = v.headingLevel(productID, field, fieldValue)
+		case "sections":
+			profile.Sections = v.sections(productID, fieldValue)
+		default:
+			v.errorf(field, "product %s: unknown parser field %q", productID, field.Value)
+		}
+	}
+
+	if profile.ReleaseHeadingLevel != 0 && profile.SectionHeadingLevel != 0 && profile.SectionHeadingLevel <= profile.ReleaseHeadingLevel {
+		v.errorf(value, "product %s: section_heading_level must be deeper than release_heading_level", productID)
+	}
+	return profile
+}
+
+// headingLevel decodes h2-h6; h1 is the page title and never parsed
+func (v *registryValidator) headingLevel(productID string, field, value *yaml.Node) int {
+	var level int
+	if err := value.Decode(&level); err != nil || level < 2 || level > 6 {
+		v.errorf(value, "product %s: %s must be a heading level from 2 to 6", productID, field.Value)
+		return 0
+	}
+	return level
+}
+
+func (v *registryValidator) sections(productID string, value *yaml.Node) map[SectionKind][]string {
+	if value.Kind != yaml.MappingNode {
+		v.errorf(value, "product %s: parser sections must map section kinds to heading names", productID)
+		return nil
+	}
+
+	sections := make(map[SectionKind][]string)
+	for i := 0; i+1 < len(value.Content); i += 2 {
+		field, fieldValue := value.Content[i], value.Content[i+1]
+		kind := SectionKind(field.Value)
+		if !knownSectionKind(kind) {
+			v.errorf(field, "product %s: unknown section kind %q", productID, field.Value)
+			continue
 		}
-		return normalized
+		sections[kind] = v.strings(productID, field, fieldValue)
 	}
+	return sections
+}
 
-	// Try "product_name" field
-	if name, ok := metadata["product_name"].(string); ok {
-		normalized := strings.ToLower(strings.TrimSpace(name))
-		if productID, found := productNameMapping[normalized]; found {
-			return productID
+// knownSectionKind reports whether a kind is one headings can be classified as
+func knownSectionKind(kind SectionKind) bool {
+	for _, entry := range sectionKeywords {
+		if entry.kind == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// Product returns the product with the given ID
+func (r *Registry) Product(productID string) (*Product, bool) {
+	for i := range r.Products {
+		if r.Products[i].ID == productID {
+			return &r.Products[i], true
+		}
+	}
+	return nil, false
+}
+
+// Identify finds the product known by a name, matching IDs, names, aliases, Pivnet slugs and
+// Ops Manager product types without regard to case
+func (r *Registry) Identify(name string) (*Product, bool) {
+	normalized := strings.ToLower(strings.TrimSpace(name))
+	if normalized == "" {
+		return nil, false
+	}
+	for i := range r.Products {
+		for _, known := range r.Products[i].names() {
+			if strings.ToLower(known) == normalized {
+				return &r.Products[i], true
+			}
 		}
-		return normalized
 	}
+	return nil, false
+}
+
+// names lists every name a product can be identified by
+func (p *Product) names() []string {
+	return append([]string{p.ID, p.Name, p.PivnetSlug, p.ProductType}, p.Aliases...)
+}
 
+// IdentifyProduct extracts and normalizes product ID from tile metadata, falling back to the
+// lowercased tile name for products not in the registry
+func (r *Registry) IdentifyProduct(metadata map[string]interface{}) string {
+	for _, field := range []string{"name", "product_name"} {
+		if name, ok := metadata[field].(string); ok {
+			if product, found := r.Identify(name); found {
+				return product.ID
+			}
+			return strings.ToLower(strings.TrimSpace(name))
+		}
+	}
 	return ""
 }
+
+// ResolveURL resolves a product ID and tile version to a release notes URL
+func (r *Registry) ResolveURL(productID, tileVersion string) (string, error) {
+	product, ok := r.Product(productID)
+	if !ok {
+		return "", fmt.Errorf("product %s not found in config", productID)
+	}
+	return product.ResolveURL(tileVersion), nil
+}
+
+// ResolveURL expands the release notes URL pattern for a tile version.
+// {version} is replaced with the documentation version (10.2.5+LTS-T -> 10-2), {line} with the
+// version line (10.2), {major}, {minor} and {patch} with those segments, and {full_version} with
+// the tile version as given.
+func (p *Product) ResolveURL(tileVersion string) string {
+	url := strings.ReplaceAll(p.ReleaseNotes, "{full_version}", tileVersion)
+	url = strings.ReplaceAll(url, "{version}", DocsVersion(tileVersion))
+	url = strings.ReplaceAll(url, "{line}", VersionLine(tileVersion))
+
+	if v, err := version.Parse(tileVersion); err == nil {
+		url = strings.ReplaceAll(url, "{major}", fmt.Sprintf("%d", v.Major()))
+		url = strings.ReplaceAll(url, "{minor}", fmt.Sprintf("%d", v.Minor()))
+		url = strings.ReplaceAll(url, "{patch}", fmt.Sprintf("%d", v.Patch()))
+	}
+	return url
+}
+
+// DocsVersion converts a tile version such as 10.2.5+LTS-T into the major-minor form
+// used in documentation URLs (10-2); versions without major.minor are returned unchanged
+func DocsVersion(tileVersion string) string {
+	v, err := version.Parse(tileVersion)
+	if err != nil || len(v.Segments) < 2 {
+		return tileVersion
+	}
+	return fmt.Sprintf("%d-%d", v.Major(), v.Minor())
+}
@@ -1,53 +1,80 @@
 // ABOUTME: Unit tests for product registry and URL resolution
-// ABOUTME: Validates config loading and version substitution in URLs
+// ABOUTME: Validates registry loading and version substitution in URLs
 package releasenotes
 
 import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 )
 
-func TestLoadProductConfig(t *testing.T) {
-	config, err := LoadProductConfig("testdata/products.yaml")
+func TestLoadRegistry(t *testing.T) {
+	registry, err := LoadRegistry("testdata/products.yaml")
 	if err != nil {
-		t.Fatalf("LoadProductConfig failed: %v", err)
+		t.Fatalf("LoadRegistry failed: %v", err)
 	}
 
-	if config["cf"] != "https://techdocs.broadcom.com/cf/{version}/release-notes.html" {
-		t.Errorf("Expected cf URL, got %s", config["cf"])
+	product, ok := registry.Product("cf")
+	if !ok {
+		t.Fatal("Expected cf product")
+	}
+	if product.ReleaseNotes != "https://techdocs.broadcom.com/cf/{version}/release-notes.html" {
+		t.Errorf("Expected cf URL, got %s", product.ReleaseNotes)
 	}
 }
 
 func TestResolveURL(t *testing.T) {
-	config := ProductConfig{
-		"cf": "https://techdocs.broadcom.com/cf/{version}/release-notes.html",
-	}
+	registry := &Registry{Products: []Product{
+		{ID: "cf", ReleaseNotes: "https://techdocs.broadcom.com/cf/{version}/release-notes.html"},
+	}}
 
-	url, err := config.ResolveURL("cf", "10.2.5")
+	url, err := registry.ResolveURL("cf", "10.2.5")
 	if err != nil {
 		t.Fatalf("ResolveURL failed: %v", err)
 	}
 
-	expected := "https://techdocs.broadcom.com/cf/10.2.5/release-notes.html"
+	expected := "https://techdocs.broadcom.com/cf/10-2/release-notes.html"
 	if url != expected {
 		t.Errorf("Expected %s, got %s", expected, url)
 	}
 }
 
 func TestResolveURL_ProductNotFound(t *testing.T) {
-	config := ProductConfig{}
+	registry := &Registry{}
 
-	_, err := config.ResolveURL("unknown", "1.0.0")
+	_, err := registry.ResolveURL("unknown", "1.0.0")
 	if err == nil {
 		t.Error("Expected error for unknown product")
 	}
 }
 
+// testRegistry returns a registry with one richly configured product
+func testRegistry(t *testing.T) *Registry {
+	t.Helper()
+	registry, err := ParseRegistry([]byte(`
+cf:
+  name: Tanzu Application Service
+  aliases: [tas]
+  pivnet_slug: elastic-runtime
+  product_type: cf
+  release_notes: "https://techdocs.broadcom.com/cf/{version}/release-notes.html"
+p-mysql: "https://techdocs.broadcom.com/mysql/{version}/release-notes.html"
+`), "products.yaml")
+	if err != nil {
+		t.Fatalf("ParseRegistry failed: %v", err)
+	}
+	return registry
+}
+
 func TestIdentifyProduct(t *testing.T) {
 	metadata := map[string]interface{}{
 		"name": "cf",
 	}
 
-	productID := IdentifyProduct(metadata)
+	productID := testRegistry(t).IdentifyProduct(metadata)
 	if productID != "cf" {
 		t.Errorf("Expected cf, got %s", productID)
 	}
@@ -58,7 +85,7 @@ func TestIdentifyProduct_Normalize(t *testing.T) {
 		"name": "Tanzu Application Service",
 	}
 
-	productID := IdentifyProduct(metadata)
+	productID := testRegistry(t).IdentifyProduct(metadata)
 	if productID != "cf" {
 		t.Errorf("Expected cf, got %s", productID)
 	}
@@ -67,8 +94,313 @@ func TestIdentifyProduct_Normalize(t *testing.T) {
 func TestIdentifyProduct_NotFound(t *testing.T) {
 	metadata := map[string]interface{}{}
 
-	productID := IdentifyProduct(metadata)
+	productID := testRegistry(t).IdentifyProduct(metadata)
 	if productID != "" {
 		t.Errorf("Expected empty string, got %s", productID)
 	}
 }
+
+func TestRegistryIdentify(t *testing.T) {
+	registry := testRegistry(t)
+
+	for _, name := range []string{"cf", "TAS", "elastic-runtime", " Tanzu Application Service "} {
+		product, ok := registry.Identify(name)
+		if !ok || product.ID != "cf" {
+			t.Errorf("Identify(%q) = %v, expected cf", name, product)
+		}
+	}
+	if product, ok := registry.Identify("p-mysql"); !ok || product.ReleaseNotes != "https://techdocs.broadcom.com/mysql/{version}/release-notes.html" {
+		t.Errorf("Expected legacy URL entry to load as a product, got %v", product)
+	}
+	if _, ok := registry.Identify("harbor-container-registry"); ok {
+		t.Error("Expected unknown product not to be identified")
+	}
+}
+
+func TestParseRegistry_Validation(t *testing.T) {
+	data := `cf:
+  aliases: [tas]
+  release_notes: "https://example.com/{verison}/rn.html"
+  pivnet_slugs: elastic-runtime
+  parser:
+    release_heading_level: 1
+    sections:
+      features: [Highlights]
+      changelog: [Changes]
+p-mysql:
+  aliases: [TAS]
+p-redis: [redis]
+`
+	_, err := ParseRegistry([]byte(data), "products.yaml")
+	if err == nil {
+		t.Fatal("Expected validation errors")
+	}
+
+	for _, expected := range []string{
+		`products.yaml:3: product cf: unknown placeholder {verison} in release_notes`,
+		`products.yaml:4: product cf: unknown field "pivnet_slugs"`,
+		`products.yaml:6: product cf: release_heading_level must be a heading level from 2 to 6`,
+		`products.yaml:9: product cf: unknown section kind "changelog"`,
+		`products.yaml:10: product p-mysql: release_notes is required`,
+		`products.yaml:11: product p-mysql: "TAS" already identifies product cf`,
+		`products.yaml:12: product p-redis: expected a release notes URL or a mapping of settings`,
+	} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("Expected error %q, got:\n%v", expected, err)
+		}
+	}
+
+	var configErr *ConfigError
+	if !errors.As(err, &configErr) || configErr.Line != 3 {
+		t.Errorf("Expected the first error to be a ConfigError on line 3, got %v", configErr)
+	}
+}
+
+func TestParseRegistry_DuplicateProduct(t *testing.T) {
+	_, err := ParseRegistry([]byte("cf: https://a.example.com\ncf: https://b.example.com\n"), "products.yaml")
+	if err == nil || !strings.Contains(err.Error(), "products.yaml:2: product cf is defined more than once") {
+		t.Errorf("Expected duplicate product error, got %v", err)
+	}
+}
+
+func TestParseRegistry_ParserProfile(t *testing.T) {
+	registry, err := ParseRegistry([]byte(`
+cf:
+  release_notes: https://example.com/{line}/rn.html
+  parser:
+    release_heading_level: 2
+    section_heading_level: 4
+    sections:
+      breaking-changes: [Upgrade Considerations]
+`), "products.yaml")
+	if err != nil {
+		t.Fatalf("ParseRegistry failed: %v", err)
+	}
+
+	product, _ := registry.Product("cf")
+	expected := ParserProfile{
+		ReleaseHeadingLevel: 2,
+		SectionHeadingLevel: 4,
+		Sections:            map[SectionKind][]string{SectionBreakingChanges: {"Upgrade Considerations"}},
+	}
+	if !reflect.DeepEqual(product.Parser, expected) {
+		t.Errorf("Expected profile %+v, got %+v", expected, product.Parser)
+	}
+
+	pages, err := registry.PagesForRange("cf", nil, "10.0.1", "10.2.5")
+	if err != nil {
+		t.Fatalf("PagesForRange failed: %v", err)
+	}
+	if len(pages) != 2 || pages[1].URL != "https://example.com/10.2/rn.html" || pages[1].Profile == nil {
+		t.Errorf("Expected pages with the product's profile, got %+v", pages)
+	}
+}
+
+func TestProductResolveURL_Segments(t *testing.T) {
+	product := &Product{ReleaseNotes: "https://example.com/{major}/{line}/{major}.{minor}.{patch}.html"}
+
+	url := product.ResolveURL("6.0.22+LTS-T")
+	if url != "https://example.com/6/6.0/6.0.22.html" {
+		t.Errorf("Unexpected URL %s", url)
+	}
+}
+
+func TestResolveURL_FullVersion(t *testing.T) {
+	registry := &Registry{Products: []Product{
+		{ID: "cf", ReleaseNotes: "https://example.com/cf/{full_version}/notes-{version}.html"},
+	}}
+
+	url, err := registry.ResolveURL("cf", "10.2.5+LTS-T")
+	if err != nil {
+		t.Fatalf("ResolveURL failed: %v", err)
+	}
+
+	expected := "https://example.com/cf/10.2.5+LTS-T/notes-10-2.html"
+	if url != expected {
+		t.Errorf("Expected %s, got %s", expected, url)
+	}
+}
+
+func TestDocsVersion(t *testing.T) {
+	tests := map[string]string{
+		"10.2.5":         "10-2",
+		"10.2.5+LTS-T":   "10-2",
+		"6.0.22-build.2": "6-0",
+		"3":              "3",
+		"10-2":           "10-2",
+		"":               "",
+	}
+
+	for input, expected := range tests {
+		if got := DocsVersion(input); got != expected {
+			t.Errorf("DocsVersion(%q) = %q, expected %q", input, got, expected)
+		}
+	}
+}
+
+func TestConfiguredProducts(t *testing.T) {
+	registry, err := DefaultRegistry()
+	if err != nil {
+		t.Fatalf("DefaultRegistry failed: %v", err)
+	}
+
+	// Tile metadata names and versions as shipped for every product in configs/products.yaml
+	tests := []struct {
+		tileName    string
+		tileVersion string
+		productID   string
+		expectedURL string
+	}{
+		{"cf", "10.2.5+LTS-T", "cf", "https://techdocs.broadcom.com/us/en/vmware-tanzu/platform/elastic-application-runtime/10-2/runtime-rn.html"},
+		{"pivotal-mysql", "3.3.0-build.12", "p-mysql", "https://techdocs.broadcom.com/us/en/vmware-tanzu/platform/tanzu-mysql-tanzu-platform/3-3/mysql-tp/release-notes.html"},
+		{"p-rabbitmq", "10.0.2", "p-rabbitmq", "https://techdocs.broadcom.com/us/en/vmware-tanzu/platform/tanzu-rabbitmq-tanzu-platform/10-0/rabbitmq-tp/releases.html"},
+		{"p-redis", "4.0.1-build.3", "p-redis", "https://techdocs.broadcom.com/us/en/vmware-tanzu/platform/tanzu-valkey-tanzu-platform/4-0/valkey-tp/release.html"},
+	}
+
+	covered := make(map[string]bool)
+	for _, tt := range tests {
+		t.Run(tt.tileName, func(t *testing.T) {
+			productID := registry.IdentifyProduct(map[string]interface{}{"name": tt.tileName})
+			if productID != tt.productID {
+				t.Fatalf("Expected product ID %s, got %s", tt.productID, productID)
+			}
+
+			url, err := registry.ResolveURL(productID, tt.tileVersion)
+			if err != nil {
+				t.Fatalf("ResolveURL failed: %v", err)
+			}
+			if url != tt.expectedURL {
+				t.Errorf("Expected %s, got %s", tt.expectedURL, url)
+			}
+		})
+		covered[tt.productID] = true
+	}
+
+	for _, product := range registry.Products {
+		if !covered[product.ID] {
+			t.Errorf("Product %s in configs/products.yaml has no test case", product.ID)
+		}
+		if product.PivnetSlug == "" || product.ProductType == "" {
+			t.Errorf("Product %s in configs/products.yaml needs a Pivnet slug and product type", product.ID)
+		}
+	}
+}
+
+func TestRegistryOverlay(t *testing.T) {
+	registry := testRegistry(t)
+
+	overlay := `
+cf:
+  aliases: [pas]
+  product_file: Small Footprint TAS*
+  parser:
+    sections:
+      features: [Highlights]
+harbor:
+  pivnet_slug: harbor-container-registry
+  release_notes: https://example.com/harbor/{version}.html
+`
+	if err := registry.Overlay([]byte(overlay), "user.yaml"); err != nil {
+		t.Fatalf("Overlay failed: %v", err)
+	}
+
+	cf, _ := registry.Product("cf")
+	if cf.ReleaseNotes != "https://techdocs.broadcom.com/cf/{version}/release-notes.html" || cf.PivnetSlug != "elastic-runtime" {
+		t.Errorf("Expected settings not in the overlay to be kept, got %+v", cf)
+	}
+	if cf.ProductFile != "Small Footprint TAS*" || !reflect.DeepEqual(cf.Aliases, []string{"tas", "pas"}) {
+		t.Errorf("Expected overlay settings to be applied, got %+v", cf)
+	}
+	if !reflect.DeepEqual(cf.Sources, []string{"products.yaml", "user.yaml"}) {
+		t.Errorf("Expected both files as sources, got %v", cf.Sources)
+	}
+	if product, ok := registry.Identify("harbor-container-registry"); !ok || product.ID != "harbor" {
+		t.Errorf("Expected new product to be added, got %v", product)
+	}
+}
+
+func TestRegistryOverlay_Invalid(t *testing.T) {
+	registry := testRegistry(t)
+
+	// A new product still needs release_notes, and names may not move between products
+	err := registry.Overlay([]byte("p-mysql:\n  aliases: [tas]\nharbor:\n  pivnet_slug: harbor-container-registry\n"), "user.yaml")
+	if err == nil {
+		t.Fatal("Expected validation errors")
+	}
+	for _, expected := range []string{
+		`user.yaml:2: product p-mysql: "tas" already identifies product cf`,
+		`user.yaml:3: product harbor: release_notes is required`,
+	} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("Expected error %q, got:\n%v", expected, err)
+		}
+	}
+
+	if _, ok := registry.Product("harbor"); ok {
+		t.Error("Expected an invalid overlay not to be merged")
+	}
+}
+
+func TestLoadEffectiveRegistry(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	if err := os.MkdirAll(filepath.Join(home, ".tile-diff"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(UserRegistryPath(), []byte("cf:\n  product_file: User TAS\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	explicit := filepath.Join(t.TempDir(), "products.yaml")
+	if err := os.WriteFile(explicit, []byte("p-redis: https://mirror.example.com/valkey/{version}.html\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	registry, err := LoadEffectiveRegistry(explicit)
+	if err != nil {
+		t.Fatalf("LoadEffectiveRegistry failed: %v", err)
+	}
+
+	cf, _ := registry.Product("cf")
+	if cf.ProductFile != "User TAS" || cf.PivnetSlug != "elastic-runtime" {
+		t.Errorf("Expected user file layered over the built-in cf entry, got %+v", cf)
+	}
+	url, _ := registry.ResolveURL("p-redis", "4.0.1")
+	if url != "https://mirror.example.com/valkey/4-0.html" {
+		t.Errorf("Expected --product-config to override the URL, got %s", url)
+	}
+	if _, ok := registry.Product("p-rabbitmq"); !ok {
+		t.Error("Expected built-in products to remain")
+	}
+
+	if _, err := LoadEffectiveRegistry(filepath.Join(home, "missing.yaml")); err == nil {
+		t.Error("Expected error for a missing --product-config file")
+	}
+}
+
+func TestRegistryMarshal(t *testing.T) {
+	registry := testRegistry(t)
+	if err := registry.Overlay([]byte("cf:\n  parser:\n    section_heading_level: 3\n"), "user.yaml"); err != nil {
+		t.Fatalf("Overlay failed: %v", err)
+	}
+
+	data, err := registry.Marshal()
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if !strings.Contains(string(data), "# From products.yaml, user.yaml\ncf:\n  name: Tanzu Application Service\n") {
+		t.Errorf("Expected products with their sources, got:\n%s", data)
+	}
+
+	// The printed registry loads back to the same products
+	reparsed, err := ParseRegistry(data, "printed.yaml")
+	if err != nil {
+		t.Fatalf("Printed registry does not parse: %v\n%s", err, data)
+	}
+	for i := range reparsed.Products {
+		reparsed.Products[i].Sources = registry.Products[i].Sources
+	}
+	if !reflect.DeepEqual(reparsed.Products, registry.Products) {
+		t.Errorf("Expected %+v, got %+v", registry.Products, reparsed.Products)
+	}
+}
@@ -0,0 +1,94 @@
+// ABOUTME: Extracts CVE, USN, and GHSA references from release notes.
+// ABOUTME: Records the release that resolved each advisory and the component it was mentioned with.
+package releasenotes
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Advisory types recognized in release notes
+const (
+	AdvisoryCVE  = "CVE"
+	AdvisoryUSN  = "USN"
+	AdvisoryGHSA = "GHSA"
+)
+
+// SecurityFix is a security advisory an upgrade resolves
+type SecurityFix struct {
+	ID        string // e.g. CVE-2024-24790, USN-6543-1, GHSA-xxxx-xxxx-xxxx
+	Type      string
+	Version   string // Release whose notes list the fix
+	Component string // Component the advisory was mentioned with, when known
+	Text      string
+}
+
+var (
+	advisoryPattern = regexp.MustCompile(`(?i)\b(CVE-\d{4}-\d{4,}|USN-\d+-\d+|GHSA(?:-[0-9a-z]{4}){3})\b`)
+
+	// bumpPattern finds the component in entries such as "Bump golang to 1.22.4"
+	bumpPattern = regexp.MustCompile(`(?i)\b(?:bumps?|upgrades?|updates?)\s+(?:the\s+)?([a-z0-9][a-z0-9_.\-]*)`)
+
+	// prefixPattern finds the component in entries such as "gorouter: fixes CVE-2024-1234"
+	prefixPattern = regexp.MustCompile(`^\[?([A-Za-z0-9][A-Za-z0-9_.\-]*)\]?:\s`)
+)
+
+// ExtractSecurityFixes finds advisories in every section except known issues, oldest release first;
+// an advisory listed in several releases is reported once, for the first release that lists it
+func ExtractSecurityFixes(notes *ReleaseNotes) []SecurityFix {
+	var fixes []SecurityFix
+	seen := make(map[string]int)
+
+	for _, note := range notes.Notes() {
+		if note.Kind == SectionKnownIssues {
+			continue
+		}
+
+		text := strings.TrimSpace(strings.Join([]string{note.Title, note.Text}, " "))
+		for _, id := range advisoryPattern.FindAllString(text, -1) {
+			id = normalizeAdvisory(id)
+			component := advisoryComponent(note)
+
+			if i, ok := seen[id]; ok {
+				if fixes[i].Component == "" {
+					fixes[i].Component = component
+				}
+				continue
+			}
+
+			seen[id] = len(fixes)
+			fixes = append(fixes, SecurityFix{
+				ID:        id,
+				Type:      id[:strings.Index(id, "-")],
+				Version:   note.Version,
+				Component: component,
+				Text:      text,
+			})
+		}
+	}
+
+	return fixes
+}
+
+// normalizeAdvisory upper-cases the advisory prefix and, except for GHSA IDs, the whole identifier
+func normalizeAdvisory(id string) string {
+	upper := strings.ToUpper(id)
+	if strings.HasPrefix(upper, AdvisoryGHSA) {
+		return AdvisoryGHSA + strings.ToLower(id[len(AdvisoryGHSA):])
+	}
+	return upper
+}
+
+// advisoryComponent determines the component an advisory was mentioned with
+func advisoryComponent(note Note) string {
+	if note.Title != "" && !advisoryPattern.MatchString(note.Title) {
+		return note.Title
+	}
+	if match := bumpPattern.FindStringSubmatch(note.Text); match != nil && !advisoryPattern.MatchString(match[1]) {
+		return match[1]
+	}
+	if match := prefixPattern.FindStringSubmatch(note.Text); match != nil && !advisoryPattern.MatchString(match[1]) {
+		return match[1]
+	}
+	return ""
+}
@@ -0,0 +1,63 @@
+// ABOUTME: Unit tests for extracting security advisories from release notes.
+// ABOUTME: Covers CVE, USN, and GHSA identifiers, component context, and de-duplication across releases.
+package releasenotes
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtractSecurityFixes(t *testing.T) {
+	html := `<html><body>
+<h2>10.2.4</h2>
+<h3>Security Fixes</h3>
+<ul>
+  <li>Bump golang to 1.22.4 to address CVE-2024-24790 and cve-2024-24789.</li>
+  <li>gorouter: fixes GHSA-ABCD-1234-wxyz in request parsing.</li>
+</ul>
+<h3>Known Issues</h3>
+<ul><li>CVE-2024-9999 is not yet fixed in the log cache.</li></ul>
+<h2>10.2.5</h2>
+<h3>Resolved Issues</h3>
+<h4>Stemcell</h4>
+<p>Addresses USN-6543-1 and CVE-2024-24790.</p>
+</body></html>`
+
+	notes, err := ParseReleaseNotes(html)
+	if err != nil {
+		t.Fatalf("ParseReleaseNotes failed: %v", err)
+	}
+
+	fixes := ExtractSecurityFixes(notes)
+
+	type summary struct{ ID, Type, Version, Component string }
+	var got []summary
+	for _, fix := range fixes {
+		got = append(got, summary{fix.ID, fix.Type, fix.Version, fix.Component})
+	}
+
+	expected := []summary{
+		{"CVE-2024-24790", AdvisoryCVE, "10.2.4", "golang"},
+		{"CVE-2024-24789", AdvisoryCVE, "10.2.4", "golang"},
+		{"GHSA-abcd-1234-wxyz", AdvisoryGHSA, "10.2.4", "gorouter"},
+		{"USN-6543-1", AdvisoryUSN, "10.2.5", "Stemcell"},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, got)
+	}
+
+	if fixes[0].Text != "Bump golang to 1.22.4 to address CVE-2024-24790 and cve-2024-24789." {
+		t.Errorf("Unexpected text: %q", fixes[0].Text)
+	}
+}
+
+func TestExtractSecurityFixes_None(t *testing.T) {
+	notes, err := ParseReleaseNotes(`<h2>10.2.5</h2><ul><li>Adds a new property.</li></ul>`)
+	if err != nil {
+		t.Fatalf("ParseReleaseNotes failed: %v", err)
+	}
+
+	if fixes := ExtractSecurityFixes(notes); len(fixes) != 0 {
+		t.Errorf("Expected no security fixes, got %+v", fixes)
+	}
+}
@@ -0,0 +1,93 @@
+// ABOUTME: Pluggable sources for reading release notes pages from a local directory or a mirror.
+// ABOUTME: Maps registry URLs to relative paths so saved copies of the documentation site can be used.
+package releasenotes
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// Source reads a release notes page; location is the URL or relative path resolved from the product registry
+type Source interface {
+	Read(location string) (string, error)
+}
+
+// DirSource reads saved release notes pages from a directory laid out like the documentation site
+type DirSource struct {
+	Dir string
+}
+
+// MirrorSource reads release notes pages from a mirror of the documentation site
+type MirrorSource struct {
+	BaseURL string
+	Fetcher *Fetcher
+}
+
+// OpenSource returns a MirrorSource for http(s) locations, fetched through fetcher, and a DirSource otherwise
+func OpenSource(location string, fetcher *Fetcher) Source {
+	if strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://") {
+		return &MirrorSource{BaseURL: location, Fetcher: fetcher}
+	}
+	return &DirSource{Dir: location}
+}
+
+// RelativePath maps a registry location to a path relative to a mirror's root: the path of an
+// absolute URL without its host (https://docs.example.com/cf/10-2/rn.html -> cf/10-2/rn.html),
+// or a relative registry entry unchanged
+func RelativePath(location string) string {
+	if parsed, err := url.Parse(location); err == nil && parsed.Scheme != "" {
+		location = parsed.Path
+	}
+	return strings.TrimPrefix(path.Clean("/"+location), "/")
+}
+
+// Read loads the page for location, trying the .html and .md variants of the file and index.html in a directory
+func (s *DirSource) Read(location string) (string, error) {
+	rel := RelativePath(location)
+	base := filepath.Join(s.Dir, filepath.FromSlash(rel))
+	stem := strings.TrimSuffix(base, filepath.Ext(base))
+
+	candidates := []string{base, stem + ".html", stem + ".md", filepath.Join(base, "index.html"), filepath.Join(base, "index.md")}
+	for _, candidate := range candidates {
+		data, err := os.ReadFile(candidate)
+		if err == nil {
+			return pageContent(candidate, string(data)), nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) && !isDirectoryError(candidate) {
+			return "", fmt.Errorf("failed to read %s: %w", candidate, err)
+		}
+	}
+
+	return "", fmt.Errorf("release notes for %s not found in %s (looked for %s)", location, s.Dir, rel)
+}
+
+// Read fetches the page for location from the mirror
+func (s *MirrorSource) Read(location string) (string, error) {
+	pageURL := strings.TrimSuffix(s.BaseURL, "/") + "/" + RelativePath(location)
+	content, err := s.Fetcher.Fetch(pageURL)
+	if err != nil {
+		return "", err
+	}
+	return pageContent(pageURL, content), nil
+}
+
+// pageContent converts Markdown pages to HTML so every page goes through the same parser
+func pageContent(name, content string) string {
+	switch strings.ToLower(path.Ext(name)) {
+	case ".md", ".markdown":
+		return MarkdownToHTML(content)
+	}
+	return content
+}
+
+// isDirectoryError reports whether path exists as a directory, which reading as a file cannot succeed on
+func isDirectoryError(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
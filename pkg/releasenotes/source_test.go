@@ -0,0 +1,146 @@
+// ABOUTME: Unit tests for reading release notes from a local directory or a mirror.
+// ABOUTME: Covers registry URL to path mapping, file fallbacks, Markdown pages, and range aggregation offline.
+package releasenotes
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRelativePath(t *testing.T) {
+	tests := []struct {
+		location string
+		expected string
+	}{
+		{"https://docs.example.com/us/en/cf/10-2/runtime-rn.html", "us/en/cf/10-2/runtime-rn.html"},
+		{"cf/10-2.md", "cf/10-2.md"},
+		{"/cf/10-2.md", "cf/10-2.md"},
+		{"../../etc/passwd", "etc/passwd"},
+	}
+
+	for _, tt := range tests {
+		if got := RelativePath(tt.location); got != tt.expected {
+			t.Errorf("RelativePath(%q) = %q, expected %q", tt.location, got, tt.expected)
+		}
+	}
+}
+
+// writeFile writes content to a path under dir, creating parent directories
+func writeFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	path := filepath.Join(dir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDirSource(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "cf/10-2/runtime-rn.html", "<h2>10.2.5</h2><p>HTML page.</p>")
+	writeFile(t, dir, "cf/6-0/runtime-rn.md", "## 6.0.23\n\n- Markdown page.\n")
+	writeFile(t, dir, "mysql/3-1/index.html", "<h2>3.1.0</h2>")
+
+	source := &DirSource{Dir: dir}
+
+	tests := []struct {
+		location string
+		contains string
+	}{
+		{"https://docs.example.com/cf/10-2/runtime-rn.html", "HTML page."},
+		{"https://docs.example.com/cf/6-0/runtime-rn.html", "<li>Markdown page.</li>"},
+		{"mysql/3-1", "<h2>3.1.0</h2>"},
+	}
+	for _, tt := range tests {
+		content, err := source.Read(tt.location)
+		if err != nil {
+			t.Errorf("Read(%q) failed: %v", tt.location, err)
+			continue
+		}
+		if !strings.Contains(content, tt.contains) {
+			t.Errorf("Read(%q) = %q, expected it to contain %q", tt.location, content, tt.contains)
+		}
+	}
+
+	if _, err := source.Read("https://docs.example.com/cf/9-9/runtime-rn.html"); err == nil || !strings.Contains(err.Error(), "cf/9-9/runtime-rn.html") {
+		t.Errorf("Expected not found error naming the path, got %v", err)
+	}
+}
+
+func TestMirrorSource(t *testing.T) {
+	server := serveReleaseNotes(t, map[string]string{
+		"/mirror/cf/10-2/runtime-rn.html": "<h2>10.2.5</h2><p>Mirrored.</p>",
+		"/mirror/cf/6-0/runtime-rn.md":    "## 6.0.23\n\nMirrored markdown.\n",
+	})
+
+	source := OpenSource(server.URL+"/mirror/", NewFetcher())
+	if _, ok := source.(*MirrorSource); !ok {
+		t.Fatalf("Expected MirrorSource for an http location, got %T", source)
+	}
+
+	content, err := source.Read("https://docs.example.com/cf/10-2/runtime-rn.html")
+	if err != nil || !strings.Contains(content, "Mirrored.") {
+		t.Errorf("Unexpected mirrored page %q, %v", content, err)
+	}
+
+	content, err = source.Read("cf/6-0/runtime-rn.md")
+	if err != nil || !strings.Contains(content, "<p>Mirrored markdown.</p>") {
+		t.Errorf("Unexpected mirrored markdown %q, %v", content, err)
+	}
+}
+
+func TestFetchReleaseNotes_FromDirectory(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "cf/10-2/rn.html", "<h2>10.2.5</h2><ul><li>Adds router_keepalive_timeout.</li></ul>")
+	writeFile(t, dir, "cf/6-0/rn.md", "## 6.0.23\n\n### New Features\n\n- Adds app_log_rate_limiting.\n")
+
+	registry := &Registry{Products: []Product{{ID: "cf", ReleaseNotes: "https://docs.example.com/cf/{version}/rn.html"}}}
+	pages, err := registry.PagesForRange("cf", []string{"6.0.23", "10.2.5"}, "6.0.22", "10.2.5")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// No server is running; every page must come from the directory
+	notes, err := FetchReleaseNotes(NewSourceFetcher(OpenSource(dir, nil)), pages, "6.0.22", "10.2.5")
+	if err != nil {
+		t.Fatalf("FetchReleaseNotes failed: %v", err)
+	}
+
+	var got []string
+	for _, note := range notes.Notes() {
+		got = append(got, note.Version+" "+note.Text)
+	}
+	expected := []string{"6.0.23 Adds app_log_rate_limiting.", "10.2.5 Adds router_keepalive_timeout."}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+}
+
+func TestNewSourceFetcher_Caches(t *testing.T) {
+	reads := 0
+	fetcher := NewSourceFetcher(sourceFunc(func(location string) (string, error) {
+		reads++
+		return "<p>" + location + "</p>", nil
+	}))
+
+	for i := 0; i < 2; i++ {
+		if _, err := fetcher.Fetch("cf/10-2"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if reads != 1 {
+		t.Errorf("Expected 1 read, got %d", reads)
+	}
+}
+
+// sourceFunc adapts a function to the Source interface
+type sourceFunc func(location string) (string, error)
+
+func (f sourceFunc) Read(location string) (string, error) {
+	return f(location)
+}
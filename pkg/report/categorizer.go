@@ -2,7 +2,12 @@
 // ABOUTME: Classifies changes into Required Actions, Warnings, and Informational.
 package report
 
-import "github.com/malston/tile-diff/pkg/compare"
+import (
+	"fmt"
+
+	"github.com/malston/tile-diff/pkg/compare"
+	"github.com/malston/tile-diff/pkg/releasenotes"
+)
 
 // Category represents the severity/type of a change
 type Category string
@@ -16,7 +21,7 @@ const (
 // CategorizedChange represents a change with its severity category
 type CategorizedChange struct {
 	compare.ComparisonResult
-	Category      Category
+	Category       Category
 	Recommendation string
 }
 
@@ -25,11 +30,36 @@ type CategorizedChanges struct {
 	RequiredActions []CategorizedChange
 	Warnings        []CategorizedChange
 	Informational   []CategorizedChange
+	Stemcells       []compare.StemcellChange
+	Releases        []compare.ReleaseChange
+	Dependencies    []compare.DependencyChange
+	Errands         []compare.ErrandChange
+	Forms           []compare.FormChange
+	Variables       []compare.VariableChange
+	UpgradeNotes    []releasenotes.Note        // Breaking changes, deprecations, and known issues from release notes
+	SecurityFixes   []releasenotes.SecurityFix // CVE, USN, and GHSA advisories resolved by the upgrade
+
+	NotesComponents     []releasenotes.ComponentChange   // Component versions changed according to release notes tables
+	ComponentMismatches []releasenotes.ComponentMismatch // Release notes component versions that differ from the tile's BOSH releases
+}
+
+// UpgradeNoteKinds are the release notes sections reported for an upgrade whether or not a property matched
+var UpgradeNoteKinds = []releasenotes.SectionKind{
+	releasenotes.SectionBreakingChanges,
+	releasenotes.SectionDeprecations,
+	releasenotes.SectionKnownIssues,
 }
 
 // CategorizeChanges classifies comparison results into severity categories
 func CategorizeChanges(changes *compare.ComparisonResults) *CategorizedChanges {
-	categorized := &CategorizedChanges{}
+	categorized := &CategorizedChanges{
+		Stemcells:    changes.Stemcells,
+		Releases:     changes.Releases,
+		Dependencies: changes.Dependencies,
+		Errands:      changes.Errands,
+		Forms:        changes.Forms,
+		Variables:    changes.Variables,
+	}
 
 	// Categorize added properties
 	for _, change := range changes.Added {
@@ -70,9 +100,32 @@ func CategorizeChanges(changes *compare.ComparisonResults) *CategorizedChanges {
 		categorized.Warnings = append(categorized.Warnings, catChange)
 	}
 
+	// Runtime configs apply to every deployment on the director, so all changes are warnings
+	for _, change := range changes.RuntimeConfigs {
+		categorized.Warnings = append(categorized.Warnings, categorizeRuntimeConfigChange(change))
+	}
+
 	return categorized
 }
 
+// categorizeRuntimeConfigChange converts a runtime config change into a warning
+func categorizeRuntimeConfigChange(change compare.RuntimeConfigChange) CategorizedChange {
+	name := fmt.Sprintf("runtime config %s", change.ConfigName)
+	if change.Subject != "" {
+		name = fmt.Sprintf("%s: %s", name, change.Subject)
+	}
+
+	return CategorizedChange{
+		ComparisonResult: compare.ComparisonResult{
+			PropertyName: name,
+			ChangeType:   change.ChangeType,
+			Description:  change.Description,
+		},
+		Category:       CategoryWarning,
+		Recommendation: "Runtime configs apply to every deployment on the BOSH Director, not just this tile - review the impact on other deployments",
+	}
+}
+
 // determineCategory determines the severity category for a change
 func determineCategory(change compare.ComparisonResult) Category {
 	switch change.ChangeType {
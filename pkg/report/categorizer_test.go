@@ -3,6 +3,7 @@
 package report
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/malston/tile-diff/pkg/compare"
@@ -128,3 +129,27 @@ func TestDetermineCategory(t *testing.T) {
 		})
 	}
 }
+
+func TestCategorizeChanges_RuntimeConfigs(t *testing.T) {
+	changes := &compare.ComparisonResults{
+		RuntimeConfigs: []compare.RuntimeConfigChange{
+			{ConfigName: "cf-dns-aliases", Subject: "addon cf-dns-aliases", ChangeType: compare.RuntimeConfigChanged, Description: "Include rules changed"},
+			{ConfigName: "new-config", ChangeType: compare.RuntimeConfigAdded, Description: "New runtime config: new-config"},
+		},
+	}
+
+	categorized := CategorizeChanges(changes)
+
+	if len(categorized.Warnings) != 2 {
+		t.Fatalf("Expected 2 warnings, got %d", len(categorized.Warnings))
+	}
+	if categorized.Warnings[0].PropertyName != "runtime config cf-dns-aliases: addon cf-dns-aliases" {
+		t.Errorf("Unexpected warning name: %s", categorized.Warnings[0].PropertyName)
+	}
+	if categorized.Warnings[1].PropertyName != "runtime config new-config" {
+		t.Errorf("Unexpected warning name: %s", categorized.Warnings[1].PropertyName)
+	}
+	if !strings.Contains(categorized.Warnings[0].Recommendation, "every deployment") {
+		t.Errorf("Expected note about other deployments, got '%s'", categorized.Warnings[0].Recommendation)
+	}
+}
@@ -0,0 +1,63 @@
+// ABOUTME: Checks product dependencies declared by the new tile against Ops Manager.
+// ABOUTME: Produces required actions for missing or out-of-range installed products.
+package report
+
+import (
+	"fmt"
+
+	"github.com/malston/tile-diff/pkg/api"
+	"github.com/malston/tile-diff/pkg/compare"
+	"github.com/malston/tile-diff/pkg/metadata"
+	"github.com/malston/tile-diff/pkg/version"
+)
+
+// opsManagerProductName is the product name tiles use to constrain the Ops Manager version
+const opsManagerProductName = "p-bosh"
+
+// CheckDependencies returns required actions for product version requirements that
+// the deployed products and Ops Manager version do not satisfy
+func CheckDependencies(requirements []metadata.ProductVersionRequirement, deployed []api.DeployedProduct, opsManagerVersion string) []CategorizedChange {
+	installed := make(map[string]string, len(deployed))
+	for _, product := range deployed {
+		installed[product.Type] = product.ProductVersion
+	}
+	if _, ok := installed[opsManagerProductName]; !ok && opsManagerVersion != "" {
+		installed[opsManagerProductName] = opsManagerVersion
+	}
+
+	var actions []CategorizedChange
+
+	for _, req := range requirements {
+		current, ok := installed[req.Name]
+		if !ok {
+			actions = append(actions, unmetDependency(req,
+				fmt.Sprintf("Required product %s (%s) is not deployed", req.Name, req.Version),
+				fmt.Sprintf("Install %s matching %s before upgrading", req.Name, req.Version)))
+			continue
+		}
+
+		satisfied, err := version.Satisfies(current, req.Version)
+		if err != nil || satisfied {
+			continue
+		}
+
+		actions = append(actions, unmetDependency(req,
+			fmt.Sprintf("Deployed %s %s does not satisfy %s", req.Name, current, req.Version),
+			fmt.Sprintf("Upgrade %s to a version matching %s before upgrading", req.Name, req.Version)))
+	}
+
+	return actions
+}
+
+// unmetDependency builds a required action for an unsatisfied product requirement
+func unmetDependency(req metadata.ProductVersionRequirement, description, recommendation string) CategorizedChange {
+	return CategorizedChange{
+		ComparisonResult: compare.ComparisonResult{
+			PropertyName: fmt.Sprintf("dependency %s", req.Name),
+			ChangeType:   compare.DependencyUnmet,
+			Description:  description,
+		},
+		Category:       CategoryRequired,
+		Recommendation: recommendation,
+	}
+}
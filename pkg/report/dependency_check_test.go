@@ -0,0 +1,63 @@
+// ABOUTME: Unit tests for product dependency checks against Ops Manager.
+// ABOUTME: Verifies unmet and missing dependencies become required actions.
+package report
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/malston/tile-diff/pkg/api"
+	"github.com/malston/tile-diff/pkg/compare"
+	"github.com/malston/tile-diff/pkg/metadata"
+)
+
+func TestCheckDependencies(t *testing.T) {
+	requirements := []metadata.ProductVersionRequirement{
+		{Name: "p-bosh", Version: "~> 3.0"},
+		{Name: "cf", Version: ">= 10.0"},
+		{Name: "p-isolation-segment", Version: ">= 10.0"},
+		{Name: "pivotal-mysql", Version: ">= 3.0"},
+	}
+	deployed := []api.DeployedProduct{
+		{Type: "cf", ProductVersion: "6.0.22"},
+		{Type: "p-isolation-segment", ProductVersion: "10.2.1"},
+	}
+
+	actions := CheckDependencies(requirements, deployed, "3.0.25-build.1")
+
+	if len(actions) != 2 {
+		t.Fatalf("Expected 2 required actions, got %d", len(actions))
+	}
+	if actions[0].PropertyName != "dependency cf" {
+		t.Errorf("Expected 'dependency cf', got '%s'", actions[0].PropertyName)
+	}
+	if !strings.Contains(actions[0].Description, "6.0.22") {
+		t.Errorf("Expected description to mention deployed version, got '%s'", actions[0].Description)
+	}
+	if actions[1].PropertyName != "dependency pivotal-mysql" {
+		t.Errorf("Expected 'dependency pivotal-mysql', got '%s'", actions[1].PropertyName)
+	}
+	if !strings.Contains(actions[1].Description, "not deployed") {
+		t.Errorf("Expected missing product description, got '%s'", actions[1].Description)
+	}
+	for _, action := range actions {
+		if action.ChangeType != compare.DependencyUnmet || action.Category != CategoryRequired {
+			t.Errorf("Expected required dependency_unmet action, got %s/%s", action.ChangeType, action.Category)
+		}
+	}
+}
+
+func TestCheckDependencies_OpsManagerVersionTooOld(t *testing.T) {
+	requirements := []metadata.ProductVersionRequirement{
+		{Name: "p-bosh", Version: "~> 3.0"},
+	}
+
+	actions := CheckDependencies(requirements, nil, "2.10.60-build.2")
+
+	if len(actions) != 1 {
+		t.Fatalf("Expected 1 required action, got %d", len(actions))
+	}
+	if !strings.Contains(actions[0].Description, "2.10.60") {
+		t.Errorf("Expected description to mention Ops Manager version, got '%s'", actions[0].Description)
+	}
+}
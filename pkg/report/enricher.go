@@ -12,6 +12,7 @@ import (
 type FeatureGroup struct {
 	Name        string
 	Description string
+	Version     string // Release that introduced the feature, when known
 	Properties  []string
 }
 
@@ -19,12 +20,14 @@ type FeatureGroup struct {
 type EnrichedChanges struct {
 	*CategorizedChanges
 	Features []FeatureGroup
+	Matches  map[string]releasenotes.Match // Release note matched to each property, keyed by property name
 }
 
 // EnrichChanges adds feature context to categorized changes
 func EnrichChanges(changes *CategorizedChanges, matches map[string]releasenotes.Match) *EnrichedChanges {
 	enriched := &EnrichedChanges{
 		CategorizedChanges: changes,
+		Matches:            matches,
 	}
 
 	// Group properties by feature
@@ -34,11 +37,13 @@ func EnrichChanges(changes *CategorizedChanges, matches map[string]releasenotes.
 	processChanges := func(changeList []CategorizedChange) {
 		for _, change := range changeList {
 			if match, ok := matches[change.PropertyName]; ok {
-				featureName := match.Feature.Title
+				// The same heading can appear in several releases' notes
+				featureName := match.Feature.Title + "\x00" + match.Feature.Version
 				if _, exists := featureMap[featureName]; !exists {
 					featureMap[featureName] = &FeatureGroup{
 						Name:        match.Feature.Title,
 						Description: match.Feature.Description,
+						Version:     match.Feature.Version,
 						Properties:  []string{},
 					}
 				}
@@ -59,3 +59,28 @@ func TestEnrichChanges(t *testing.T) {
 		t.Errorf("Expected 1 property in feature, got %d", len(feature.Properties))
 	}
 }
+
+func TestEnrichChanges_SeparatesReleases(t *testing.T) {
+	matches := map[string]releasenotes.Match{
+		"router_timeout": {Property: "router_timeout", Feature: releasenotes.Feature{Title: "New Features", Version: "6.0.23"}},
+		"log_rate":       {Property: "log_rate", Feature: releasenotes.Feature{Title: "New Features", Version: "10.2.5"}},
+	}
+
+	changes := &CategorizedChanges{
+		Informational: []CategorizedChange{
+			{ComparisonResult: compare.ComparisonResult{PropertyName: "router_timeout"}},
+			{ComparisonResult: compare.ComparisonResult{PropertyName: "log_rate"}},
+		},
+	}
+
+	enriched := EnrichChanges(changes, matches)
+
+	if len(enriched.Features) != 2 {
+		t.Fatalf("Expected a feature group per release, got %d", len(enriched.Features))
+	}
+	for _, feature := range enriched.Features {
+		if len(feature.Properties) != 1 || feature.Version == "" {
+			t.Errorf("Unexpected feature group: %+v", feature)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+// ABOUTME: Checks errand changes against the product's current Ops Manager errand configuration.
+// ABOUTME: Produces warnings for errands that will start running by default after the upgrade.
+package report
+
+import (
+	"fmt"
+
+	"github.com/malston/tile-diff/pkg/api"
+	"github.com/malston/tile-diff/pkg/compare"
+)
+
+// CheckErrands returns warnings for errands that start running by default in the new tile
+// and are not explicitly configured in Ops Manager
+func CheckErrands(changes []compare.ErrandChange, current []api.Errand) []CategorizedChange {
+	configured := make(map[string]api.Errand, len(current))
+	for _, errand := range current {
+		configured[errand.Name] = errand
+	}
+
+	var warnings []CategorizedChange
+
+	for _, change := range changes {
+		if !change.StartsRunningByDefault() {
+			continue
+		}
+
+		errand, exists := configured[change.Name]
+		if exists && !followsDefault(errandSetting(errand, change.Lifecycle)) {
+			continue
+		}
+
+		warnings = append(warnings, CategorizedChange{
+			ComparisonResult: compare.ComparisonResult{
+				PropertyName: fmt.Sprintf("%s errand %s", change.Lifecycle, change.Name),
+				ChangeType:   compare.ErrandEnabled,
+				Description:  fmt.Sprintf("Errand will run by default (%s) after upgrade", change.NewDefault),
+			},
+			Category:       CategoryWarning,
+			Recommendation: "Expect longer Apply Changes, or disable the errand explicitly before upgrading",
+		})
+	}
+
+	return warnings
+}
+
+// errandSetting returns the configured run state for the errand's lifecycle
+func errandSetting(errand api.Errand, lifecycle string) interface{} {
+	if lifecycle == compare.PreDeleteErrand {
+		return errand.PreDelete
+	}
+	return errand.PostDeploy
+}
+
+// followsDefault reports whether an Ops Manager errand setting defers to the tile's run_default
+func followsDefault(setting interface{}) bool {
+	return setting == nil || setting == "default"
+}
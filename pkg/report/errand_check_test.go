@@ -0,0 +1,43 @@
+// ABOUTME: Unit tests for errand checks against Ops Manager errand configuration.
+// ABOUTME: Verifies newly enabled errands become warnings unless explicitly configured.
+package report
+
+import (
+	"testing"
+
+	"github.com/malston/tile-diff/pkg/api"
+	"github.com/malston/tile-diff/pkg/compare"
+)
+
+func TestCheckErrands(t *testing.T) {
+	changes := []compare.ErrandChange{
+		{Name: "push-usage-service", Lifecycle: compare.PostDeployErrand, ChangeType: compare.ErrandChanged, OldDefault: "off", NewDefault: "on"},
+		{Name: "smoke_tests", Lifecycle: compare.PostDeployErrand, ChangeType: compare.ErrandChanged, OldDefault: "off", NewDefault: "on"},
+		{Name: "rotate_cc_database_key", Lifecycle: compare.PostDeployErrand, ChangeType: compare.ErrandAdded, NewDefault: "when-changed"},
+		{Name: "deploy-notifications", Lifecycle: compare.PostDeployErrand, ChangeType: compare.ErrandRemoved, OldDefault: "on"},
+		{Name: "delete-apps-manager", Lifecycle: compare.PreDeleteErrand, ChangeType: compare.ErrandChanged, OldDefault: "on", NewDefault: "off"},
+	}
+	current := []api.Errand{
+		{Name: "push-usage-service", PostDeploy: "default"},
+		{Name: "smoke_tests", PostDeploy: false},
+		{Name: "deploy-notifications", PostDeploy: true},
+		{Name: "delete-apps-manager", PreDelete: true},
+	}
+
+	warnings := CheckErrands(changes, current)
+
+	if len(warnings) != 2 {
+		t.Fatalf("Expected 2 warnings, got %d", len(warnings))
+	}
+	if warnings[0].PropertyName != "post-deploy errand push-usage-service" {
+		t.Errorf("Expected push-usage-service warning, got '%s'", warnings[0].PropertyName)
+	}
+	if warnings[1].PropertyName != "post-deploy errand rotate_cc_database_key" {
+		t.Errorf("Expected rotate_cc_database_key warning, got '%s'", warnings[1].PropertyName)
+	}
+	for _, warning := range warnings {
+		if warning.Category != CategoryWarning || warning.ChangeType != compare.ErrandEnabled {
+			t.Errorf("Expected errand_enabled warning, got %s/%s", warning.ChangeType, warning.Category)
+		}
+	}
+}
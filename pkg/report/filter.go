@@ -10,6 +10,13 @@ func FilterRelevantChanges(allChanges *compare.ComparisonResults, currentConfig
 		TotalOldProps:    allChanges.TotalOldProps,
 		TotalNewProps:    allChanges.TotalNewProps,
 		ConfigurableOnly: allChanges.ConfigurableOnly,
+		Stemcells:        allChanges.Stemcells,
+		Releases:         allChanges.Releases,
+		Dependencies:     allChanges.Dependencies,
+		Errands:          allChanges.Errands,
+		Forms:            allChanges.Forms,
+		Variables:        allChanges.Variables,
+		RuntimeConfigs:   allChanges.RuntimeConfigs,
 	}
 
 	// Filter added properties (always relevant)
@@ -121,3 +121,17 @@ func TestIsChangeRelevant(t *testing.T) {
 		})
 	}
 }
+
+func TestFilterRelevantChanges_KeepsRuntimeConfigs(t *testing.T) {
+	allChanges := &compare.ComparisonResults{
+		RuntimeConfigs: []compare.RuntimeConfigChange{
+			{ConfigName: "cf-dns-aliases", ChangeType: compare.RuntimeConfigChanged},
+		},
+	}
+
+	filtered := FilterRelevantChanges(allChanges, &CurrentConfig{Properties: map[string]ConfiguredProperty{}})
+
+	if len(filtered.RuntimeConfigs) != 1 {
+		t.Errorf("Expected runtime config changes to be kept, got %d", len(filtered.RuntimeConfigs))
+	}
+}
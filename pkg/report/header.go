@@ -0,0 +1,80 @@
+// ABOUTME: Describes the tiles being compared so archived reports are self-describing.
+// ABOUTME: Collects product name and version from metadata plus file name and SHA256 from disk.
+package report
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/malston/tile-diff/pkg/metadata"
+)
+
+// TileInfo identifies one side of a tile comparison
+type TileInfo struct {
+	ProductName string
+	Version     string
+	FileName    string
+	SHA256      string
+}
+
+// ReportHeader identifies the old and new tiles a report compares
+type ReportHeader struct {
+	Old TileInfo
+	New TileInfo
+}
+
+// NewTileInfo describes the tile loaded from location. A known checksum, such as the one Pivnet publishes,
+// is used as given; otherwise SHA256 is only computed for local .pivotal archives, since the checksum of
+// an extracted metadata file or directory does not identify the tile
+func NewTileInfo(location string, tile *metadata.TileMetadata, knownSHA256 string) (TileInfo, error) {
+	info := TileInfo{
+		ProductName: tile.Name,
+		Version:     tile.ProductVersion,
+		FileName:    path.Base(strings.TrimRight(strings.ReplaceAll(location, "\\", "/"), "/")),
+		SHA256:      knownSHA256,
+	}
+
+	if knownSHA256 != "" {
+		return info, nil
+	}
+	if kind, err := metadata.DetectSource(location); err != nil || kind != metadata.SourceZip {
+		return info, nil
+	}
+
+	sum, err := fileSHA256(location)
+	if err != nil {
+		return info, err
+	}
+	info.SHA256 = sum
+
+	return info, nil
+}
+
+// Label returns the version, falling back to the file name when metadata has no product_version
+func (t TileInfo) Label() string {
+	if t.Version != "" {
+		return t.Version
+	}
+	return t.FileName
+}
+
+// fileSHA256 streams a file through SHA-256
+func fileSHA256(filePath string) (string, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to open %s: %w", filePath, err)
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", fmt.Errorf("failed to hash %s: %w", filePath, err)
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
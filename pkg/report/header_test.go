@@ -0,0 +1,92 @@
+// ABOUTME: Unit tests for describing compared tiles in report headers.
+// ABOUTME: Validates metadata identity, file names, SHA256 hashing, and version labels.
+package report
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/malston/tile-diff/pkg/metadata"
+)
+
+// testHeader identifies the tiles used throughout the report tests
+var testHeader = ReportHeader{
+	Old: TileInfo{ProductName: "cf", Version: "6.0.22"},
+	New: TileInfo{ProductName: "cf", Version: "10.2.5"},
+}
+
+func TestNewTileInfo(t *testing.T) {
+	tilePath := filepath.Join(t.TempDir(), "srt-10.2.5-build.2.pivotal")
+	if err := os.WriteFile(tilePath, []byte("PK\x03\x04tile contents"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := NewTileInfo(tilePath, &metadata.TileMetadata{Name: "cf", ProductVersion: "10.2.5-build.2"}, "")
+	if err != nil {
+		t.Fatalf("NewTileInfo failed: %v", err)
+	}
+
+	if info.ProductName != "cf" || info.Version != "10.2.5-build.2" {
+		t.Errorf("Unexpected identity: %+v", info)
+	}
+	if info.FileName != "srt-10.2.5-build.2.pivotal" {
+		t.Errorf("Expected file name srt-10.2.5-build.2.pivotal, got %s", info.FileName)
+	}
+	if info.SHA256 != "7dd71ce680eb7dcdafb01b7c1f3796a88fea948338ecd521e0c04cb8bc845374" {
+		t.Errorf("Unexpected SHA256: %s", info.SHA256)
+	}
+}
+
+func TestNewTileInfo_NonFileSources(t *testing.T) {
+	tile := &metadata.TileMetadata{Name: "cf", ProductVersion: "6.0.22"}
+	dir := filepath.Join(t.TempDir(), "srt-6.0.22")
+	if err := os.Mkdir(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	metadataFile := filepath.Join(t.TempDir(), "elastic-runtime-6.0.22-1234.yml")
+	if err := os.WriteFile(metadataFile, []byte("name: cf\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for location, fileName := range map[string]string{
+		"s3://tiles/cf/srt-6.0.22.pivotal": "srt-6.0.22.pivotal",
+		dir + "/":                          "srt-6.0.22",
+		metadataFile:                       "elastic-runtime-6.0.22-1234.yml",
+	} {
+		info, err := NewTileInfo(location, tile, "")
+		if err != nil {
+			t.Fatalf("NewTileInfo(%s) failed: %v", location, err)
+		}
+		if info.SHA256 != "" {
+			t.Errorf("Expected no SHA256 for %s, got %s", location, info.SHA256)
+		}
+		if info.FileName != fileName {
+			t.Errorf("Expected file name %s, got %s", fileName, info.FileName)
+		}
+	}
+}
+
+func TestNewTileInfo_KnownChecksum(t *testing.T) {
+	tilePath := filepath.Join(t.TempDir(), "srt-10.2.5.pivotal")
+	if err := os.WriteFile(tilePath, []byte("PK\x03\x04tile contents"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := NewTileInfo(tilePath, &metadata.TileMetadata{Name: "cf", ProductVersion: "10.2.5"}, "41c8f03b")
+	if err != nil {
+		t.Fatalf("NewTileInfo failed: %v", err)
+	}
+	if info.SHA256 != "41c8f03b" {
+		t.Errorf("Expected the known checksum to be used instead of hashing, got %s", info.SHA256)
+	}
+}
+
+func TestTileInfoLabel(t *testing.T) {
+	if label := (TileInfo{Version: "10.2.5", FileName: "srt.pivotal"}).Label(); label != "10.2.5" {
+		t.Errorf("Expected version label, got %s", label)
+	}
+	if label := (TileInfo{FileName: "srt.pivotal"}).Label(); label != "srt.pivotal" {
+		t.Errorf("Expected file name fallback, got %s", label)
+	}
+}
@@ -8,12 +8,33 @@ import (
 
 // JSONReport represents the JSON report structure
 type JSONReport struct {
-	OldVersion      string                `json:"old_version"`
-	NewVersion      string                `json:"new_version"`
-	Summary         JSONSummary           `json:"summary"`
-	RequiredActions []JSONChange          `json:"required_actions"`
-	Warnings        []JSONChange          `json:"warnings"`
-	Informational   []JSONChange          `json:"informational"`
+	OldVersion        string                 `json:"old_version"`
+	NewVersion        string                 `json:"new_version"`
+	OldTile           JSONTileInfo           `json:"old_tile"`
+	NewTile           JSONTileInfo           `json:"new_tile"`
+	Summary           JSONSummary            `json:"summary"`
+	RequiredActions   []JSONChange           `json:"required_actions"`
+	Warnings          []JSONChange           `json:"warnings"`
+	Informational     []JSONChange           `json:"informational"`
+	Stemcells         []JSONStemcellChange   `json:"stemcells,omitempty"`
+	ComponentVersions []JSONReleaseChange    `json:"component_versions,omitempty"`
+	Dependencies      []JSONDependencyChange `json:"dependencies,omitempty"`
+	Errands           []JSONErrandChange     `json:"errands,omitempty"`
+	Forms             []JSONFormChange       `json:"forms,omitempty"`
+	Credentials       []JSONVariableChange   `json:"credentials,omitempty"`
+	UpgradeNotes      []JSONUpgradeNote      `json:"upgrade_notes,omitempty"`
+	SecurityFixes     []JSONSecurityFix      `json:"security_fixes,omitempty"`
+
+	ReleaseNotesComponents []JSONComponentChange   `json:"release_notes_components,omitempty"`
+	ComponentMismatches    []JSONComponentMismatch `json:"component_mismatches,omitempty"`
+}
+
+// JSONTileInfo identifies one of the compared tiles in JSON format
+type JSONTileInfo struct {
+	ProductName string `json:"product_name,omitempty"`
+	Version     string `json:"version,omitempty"`
+	FileName    string `json:"file_name,omitempty"`
+	SHA256      string `json:"sha256,omitempty"`
 }
 
 // JSONSummary contains summary statistics
@@ -32,13 +53,107 @@ type JSONChange struct {
 	Description    string `json:"description"`
 	Recommendation string `json:"recommendation"`
 	PropertyType   string `json:"property_type,omitempty"`
+	Form           string `json:"form,omitempty"`
+	FormLabel      string `json:"form_label,omitempty"`
+	PropertyLabel  string `json:"property_label,omitempty"`
+}
+
+// JSONStemcellChange represents a stemcell criteria change in JSON format
+type JSONStemcellChange struct {
+	OS          string `json:"os"`
+	ChangeType  string `json:"change_type"`
+	OldVersion  string `json:"old_version,omitempty"`
+	NewVersion  string `json:"new_version,omitempty"`
+	Description string `json:"description"`
+}
+
+// JSONReleaseChange represents a BOSH release version change in JSON format
+type JSONReleaseChange struct {
+	Name        string `json:"name"`
+	ChangeType  string `json:"change_type"`
+	OldVersion  string `json:"old_version,omitempty"`
+	NewVersion  string `json:"new_version,omitempty"`
+	File        string `json:"file,omitempty"`
+	FileMissing bool   `json:"file_missing,omitempty"`
+}
+
+// JSONDependencyChange represents a required product version change in JSON format
+type JSONDependencyChange struct {
+	Name          string `json:"name"`
+	ChangeType    string `json:"change_type"`
+	OldConstraint string `json:"old_constraint,omitempty"`
+	NewConstraint string `json:"new_constraint,omitempty"`
+	Description   string `json:"description"`
+}
+
+// JSONErrandChange represents an errand change in JSON format
+type JSONErrandChange struct {
+	Name        string `json:"name"`
+	Lifecycle   string `json:"lifecycle"`
+	ChangeType  string `json:"change_type"`
+	OldDefault  string `json:"old_default,omitempty"`
+	NewDefault  string `json:"new_default,omitempty"`
+	Description string `json:"description"`
+}
+
+// JSONFormChange represents an Ops Manager form tab change in JSON format
+type JSONFormChange struct {
+	Name        string `json:"name"`
+	Label       string `json:"label"`
+	ChangeType  string `json:"change_type"`
+	Description string `json:"description"`
+}
+
+// JSONVariableChange represents a credential variable change in JSON format
+type JSONVariableChange struct {
+	Name           string   `json:"name"`
+	Type           string   `json:"type"`
+	ChangeType     string   `json:"change_type"`
+	ChangedOptions []string `json:"changed_options,omitempty"`
+	RotationRisk   bool     `json:"rotation_risk"`
+	Description    string   `json:"description"`
+}
+
+// JSONUpgradeNote represents a breaking change, deprecation, or known issue from release notes in JSON format
+type JSONUpgradeNote struct {
+	Version string `json:"version"`
+	Kind    string `json:"kind"`
+	Section string `json:"section"`
+	Title   string `json:"title,omitempty"`
+	Text    string `json:"text"`
+}
+
+// JSONSecurityFix represents a security advisory resolved by the upgrade in JSON format
+type JSONSecurityFix struct {
+	ID        string `json:"id"`
+	Type      string `json:"type"`
+	Version   string `json:"version"`
+	Component string `json:"component,omitempty"`
+	Text      string `json:"text"`
+}
+
+// JSONComponentChange represents a component version change from release notes tables in JSON format
+type JSONComponentChange struct {
+	Name       string `json:"name"`
+	OldVersion string `json:"old_version,omitempty"`
+	NewVersion string `json:"new_version,omitempty"`
+}
+
+// JSONComponentMismatch represents a release notes component version that differs from the tile in JSON format
+type JSONComponentMismatch struct {
+	Name         string `json:"name"`
+	Release      string `json:"release"`
+	NotesVersion string `json:"notes_version"`
+	TileVersion  string `json:"tile_version"`
 }
 
 // GenerateJSONReport creates a JSON-formatted report from categorized changes
-func GenerateJSONReport(categorized *CategorizedChanges, oldVersion, newVersion string) string {
+func GenerateJSONReport(categorized *CategorizedChanges, header ReportHeader) string {
 	report := JSONReport{
-		OldVersion: oldVersion,
-		NewVersion: newVersion,
+		OldVersion: header.Old.Label(),
+		NewVersion: header.New.Label(),
+		OldTile:    toJSONTileInfo(header.Old),
+		NewTile:    toJSONTileInfo(header.New),
 		Summary: JSONSummary{
 			TotalChanges:    len(categorized.RequiredActions) + len(categorized.Warnings) + len(categorized.Informational),
 			RequiredActions: len(categorized.RequiredActions),
@@ -62,10 +177,127 @@ func GenerateJSONReport(categorized *CategorizedChanges, oldVersion, newVersion
 		report.Informational = append(report.Informational, toJSONChange(change))
 	}
 
+	// Convert stemcell changes
+	for _, change := range categorized.Stemcells {
+		report.Stemcells = append(report.Stemcells, JSONStemcellChange{
+			OS:          change.OS,
+			ChangeType:  string(change.ChangeType),
+			OldVersion:  change.OldVersion,
+			NewVersion:  change.NewVersion,
+			Description: change.Description,
+		})
+	}
+
+	// Convert release changes
+	for _, change := range categorized.Releases {
+		report.ComponentVersions = append(report.ComponentVersions, JSONReleaseChange{
+			Name:        change.Name,
+			ChangeType:  string(change.ChangeType),
+			OldVersion:  change.OldVersion,
+			NewVersion:  change.NewVersion,
+			File:        change.File,
+			FileMissing: change.FileMissing,
+		})
+	}
+
+	// Convert dependency changes
+	for _, change := range categorized.Dependencies {
+		report.Dependencies = append(report.Dependencies, JSONDependencyChange{
+			Name:          change.Name,
+			ChangeType:    string(change.ChangeType),
+			OldConstraint: change.OldConstraint,
+			NewConstraint: change.NewConstraint,
+			Description:   change.Description,
+		})
+	}
+
+	// Convert errand changes
+	for _, change := range categorized.Errands {
+		report.Errands = append(report.Errands, JSONErrandChange{
+			Name:        change.Name,
+			Lifecycle:   change.Lifecycle,
+			ChangeType:  string(change.ChangeType),
+			OldDefault:  change.OldDefault,
+			NewDefault:  change.NewDefault,
+			Description: change.Description,
+		})
+	}
+
+	// Convert form changes
+	for _, change := range categorized.Forms {
+		report.Forms = append(report.Forms, JSONFormChange{
+			Name:        change.Name,
+			Label:       change.Label,
+			ChangeType:  string(change.ChangeType),
+			Description: change.Description,
+		})
+	}
+
+	// Convert credential variable changes
+	for _, change := range categorized.Variables {
+		report.Credentials = append(report.Credentials, JSONVariableChange{
+			Name:           change.Name,
+			Type:           change.Type,
+			ChangeType:     string(change.ChangeType),
+			ChangedOptions: change.ChangedOptions,
+			RotationRisk:   change.RotationRisk,
+			Description:    change.Description,
+		})
+	}
+
+	// Convert release notes upgrade notes
+	for _, note := range categorized.UpgradeNotes {
+		report.UpgradeNotes = append(report.UpgradeNotes, JSONUpgradeNote{
+			Version: note.Version,
+			Kind:    string(note.Kind),
+			Section: note.Section,
+			Title:   note.Title,
+			Text:    note.Text,
+		})
+	}
+
+	// Convert security fixes
+	for _, fix := range categorized.SecurityFixes {
+		report.SecurityFixes = append(report.SecurityFixes, JSONSecurityFix{
+			ID:        fix.ID,
+			Type:      fix.Type,
+			Version:   fix.Version,
+			Component: fix.Component,
+			Text:      fix.Text,
+		})
+	}
+
+	// Convert release notes component versions
+	for _, change := range categorized.NotesComponents {
+		report.ReleaseNotesComponents = append(report.ReleaseNotesComponents, JSONComponentChange{
+			Name:       change.Name,
+			OldVersion: change.OldVersion,
+			NewVersion: change.NewVersion,
+		})
+	}
+	for _, mismatch := range categorized.ComponentMismatches {
+		report.ComponentMismatches = append(report.ComponentMismatches, JSONComponentMismatch{
+			Name:         mismatch.Name,
+			Release:      mismatch.Release,
+			NotesVersion: mismatch.NotesVersion,
+			TileVersion:  mismatch.TileVersion,
+		})
+	}
+
 	jsonBytes, _ := json.MarshalIndent(report, "", "  ")
 	return string(jsonBytes)
 }
 
+// toJSONTileInfo converts a TileInfo to JSONTileInfo
+func toJSONTileInfo(tile TileInfo) JSONTileInfo {
+	return JSONTileInfo{
+		ProductName: tile.ProductName,
+		Version:     tile.Version,
+		FileName:    tile.FileName,
+		SHA256:      tile.SHA256,
+	}
+}
+
 // toJSONChange converts a CategorizedChange to JSONChange
 func toJSONChange(change CategorizedChange) JSONChange {
 	jsonChange := JSONChange{
@@ -74,6 +306,9 @@ func toJSONChange(change CategorizedChange) JSONChange {
 		Category:       string(change.Category),
 		Description:    change.Description,
 		Recommendation: change.Recommendation,
+		Form:           change.FormName,
+		FormLabel:      change.FormLabel,
+		PropertyLabel:  change.PropertyLabel,
 	}
 
 	if change.NewProperty != nil {
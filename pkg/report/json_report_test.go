@@ -8,6 +8,7 @@ import (
 
 	"github.com/malston/tile-diff/pkg/compare"
 	"github.com/malston/tile-diff/pkg/metadata"
+	"github.com/malston/tile-diff/pkg/releasenotes"
 )
 
 func TestGenerateJSONReport(t *testing.T) {
@@ -27,7 +28,7 @@ func TestGenerateJSONReport(t *testing.T) {
 		},
 	}
 
-	jsonReport := GenerateJSONReport(categorized, "6.0.22", "10.2.5")
+	jsonReport := GenerateJSONReport(categorized, testHeader)
 
 	// Verify it's valid JSON
 	var result map[string]interface{}
@@ -62,3 +63,194 @@ func TestGenerateJSONReport(t *testing.T) {
 		t.Errorf("Expected 1 action, got %d", len(actions))
 	}
 }
+
+func TestGenerateJSONReport_Sections(t *testing.T) {
+	tests := []struct {
+		section     string
+		categorized *CategorizedChanges
+		check       func(t *testing.T, result JSONReport)
+	}{
+		{
+			section: "stemcells",
+			categorized: &CategorizedChanges{
+				Stemcells: []compare.StemcellChange{
+					{OS: "windows2019", ChangeType: compare.StemcellAdded, NewVersion: "2019.70", Description: "New stemcell line required: windows2019 2019.70"},
+				},
+			},
+			check: func(t *testing.T, result JSONReport) {
+				if len(result.Stemcells) != 1 || result.Stemcells[0].OS != "windows2019" || result.Stemcells[0].ChangeType != "stemcell_added" {
+					t.Errorf("Expected added windows2019 stemcell, got %+v", result.Stemcells)
+				}
+			},
+		},
+		{
+			section: "releases",
+			categorized: &CategorizedChanges{
+				Releases: []compare.ReleaseChange{
+					{Name: "capi", ChangeType: compare.ReleaseUpdated, OldVersion: "1.2.3", NewVersion: "1.5.0", File: "capi-1.5.0.tgz"},
+				},
+			},
+			check: func(t *testing.T, result JSONReport) {
+				if len(result.ComponentVersions) != 1 || result.ComponentVersions[0].Name != "capi" || result.ComponentVersions[0].NewVersion != "1.5.0" {
+					t.Errorf("Expected capi 1.5.0, got %+v", result.ComponentVersions)
+				}
+			},
+		},
+		{
+			section: "dependencies",
+			categorized: &CategorizedChanges{
+				Dependencies: []compare.DependencyChange{
+					{Name: "cf", ChangeType: compare.DependencyAdded, NewConstraint: ">= 10.0", Description: "New dependency on cf >= 10.0"},
+				},
+			},
+			check: func(t *testing.T, result JSONReport) {
+				if len(result.Dependencies) != 1 || result.Dependencies[0].Name != "cf" || result.Dependencies[0].NewConstraint != ">= 10.0" {
+					t.Errorf("Expected cf >= 10.0, got %+v", result.Dependencies)
+				}
+			},
+		},
+		{
+			section: "errands",
+			categorized: &CategorizedChanges{
+				Errands: []compare.ErrandChange{
+					{Name: "push-usage-service", Lifecycle: compare.PostDeployErrand, ChangeType: compare.ErrandChanged, OldDefault: "off", NewDefault: "on"},
+				},
+			},
+			check: func(t *testing.T, result JSONReport) {
+				if len(result.Errands) != 1 || result.Errands[0].Lifecycle != "post-deploy" || result.Errands[0].NewDefault != "on" {
+					t.Errorf("Expected post-deploy errand now on, got %+v", result.Errands)
+				}
+			},
+		},
+		{
+			section: "forms",
+			categorized: &CategorizedChanges{
+				Warnings: []CategorizedChange{
+					{
+						ComparisonResult: compare.ComparisonResult{PropertyName: "routing_tls_termination", ChangeType: compare.TypeChanged, FormName: "networking", FormLabel: "Networking", PropertyLabel: "TLS termination point"},
+						Category:         CategoryWarning,
+					},
+				},
+				Forms: []compare.FormChange{
+					{Name: "credhub", Label: "CredHub", ChangeType: compare.FormRemoved, Description: "Form tab removed: CredHub"},
+				},
+			},
+			check: func(t *testing.T, result JSONReport) {
+				if len(result.Warnings) != 1 || result.Warnings[0].FormLabel != "Networking" || result.Warnings[0].PropertyLabel != "TLS termination point" {
+					t.Errorf("Expected form placement on warning, got %+v", result.Warnings)
+				}
+				if len(result.Forms) != 1 || result.Forms[0].ChangeType != "form_removed" {
+					t.Errorf("Expected removed credhub form, got %+v", result.Forms)
+				}
+			},
+		},
+		{
+			section: "variables",
+			categorized: &CategorizedChanges{
+				Variables: []compare.VariableChange{
+					{Name: "gorouter-cert", Type: "certificate", ChangeType: compare.VariableChanged, ChangedOptions: []string{"alternative_names"}, RotationRisk: true},
+				},
+			},
+			check: func(t *testing.T, result JSONReport) {
+				if len(result.Credentials) != 1 || !result.Credentials[0].RotationRisk || result.Credentials[0].ChangedOptions[0] != "alternative_names" {
+					t.Errorf("Expected rotation-risk change to alternative_names, got %+v", result.Credentials)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.section, func(t *testing.T) {
+			var result JSONReport
+			if err := json.Unmarshal([]byte(GenerateJSONReport(tt.categorized, testHeader)), &result); err != nil {
+				t.Fatalf("Invalid JSON: %v", err)
+			}
+			tt.check(t, result)
+		})
+	}
+}
+
+func TestGenerateJSONReport_Header(t *testing.T) {
+	header := ReportHeader{
+		Old: TileInfo{ProductName: "cf", Version: "6.0.22", FileName: "srt-6.0.22.pivotal", SHA256: "aaaa"},
+		New: TileInfo{ProductName: "cf", Version: "10.2.5", FileName: "srt-10.2.5.pivotal", SHA256: "bbbb"},
+	}
+
+	var parsed JSONReport
+	if err := json.Unmarshal([]byte(GenerateJSONReport(&CategorizedChanges{}, header)), &parsed); err != nil {
+		t.Fatalf("Invalid JSON: %v", err)
+	}
+
+	if parsed.OldVersion != "6.0.22" || parsed.NewVersion != "10.2.5" {
+		t.Errorf("Expected versions 6.0.22 -> 10.2.5, got %s -> %s", parsed.OldVersion, parsed.NewVersion)
+	}
+	expectedNew := JSONTileInfo{ProductName: "cf", Version: "10.2.5", FileName: "srt-10.2.5.pivotal", SHA256: "bbbb"}
+	if parsed.NewTile != expectedNew {
+		t.Errorf("Unexpected new_tile: %+v", parsed.NewTile)
+	}
+	if parsed.OldTile.SHA256 != "aaaa" {
+		t.Errorf("Unexpected old_tile: %+v", parsed.OldTile)
+	}
+}
+
+func TestGenerateJSONReport_UpgradeNotes(t *testing.T) {
+	categorized := &CategorizedChanges{
+		UpgradeNotes: []releasenotes.Note{
+			{Version: "10.2.5", Kind: releasenotes.SectionKnownIssues, Section: "Known Issues", Title: "Diego cells may restart", Text: "Upgrade Ops Manager first."},
+		},
+	}
+
+	var parsed JSONReport
+	if err := json.Unmarshal([]byte(GenerateJSONReport(categorized, testHeader)), &parsed); err != nil {
+		t.Fatalf("Invalid JSON: %v", err)
+	}
+
+	expected := []JSONUpgradeNote{{Version: "10.2.5", Kind: "known-issues", Section: "Known Issues", Title: "Diego cells may restart", Text: "Upgrade Ops Manager first."}}
+	if len(parsed.UpgradeNotes) != 1 || parsed.UpgradeNotes[0] != expected[0] {
+		t.Errorf("Expected %+v, got %+v", expected, parsed.UpgradeNotes)
+	}
+}
+
+func TestGenerateJSONReport_SecurityFixes(t *testing.T) {
+	categorized := &CategorizedChanges{
+		SecurityFixes: []releasenotes.SecurityFix{
+			{ID: "CVE-2024-24790", Type: "CVE", Version: "10.2.4", Component: "golang", Text: "Bump golang to 1.22.4 to address CVE-2024-24790."},
+		},
+	}
+
+	var parsed JSONReport
+	if err := json.Unmarshal([]byte(GenerateJSONReport(categorized, testHeader)), &parsed); err != nil {
+		t.Fatalf("Invalid JSON: %v", err)
+	}
+
+	expected := JSONSecurityFix{ID: "CVE-2024-24790", Type: "CVE", Version: "10.2.4", Component: "golang", Text: "Bump golang to 1.22.4 to address CVE-2024-24790."}
+	if len(parsed.SecurityFixes) != 1 || parsed.SecurityFixes[0] != expected {
+		t.Errorf("Expected %+v, got %+v", expected, parsed.SecurityFixes)
+	}
+}
+
+func TestGenerateJSONReport_NotesComponents(t *testing.T) {
+	categorized := &CategorizedChanges{
+		NotesComponents: []releasenotes.ComponentChange{
+			{Name: "gorouter", OldVersion: "0.300.0", NewVersion: "0.301.0"},
+		},
+		ComponentMismatches: []releasenotes.ComponentMismatch{
+			{Name: "gorouter", Release: "routing", NotesVersion: "0.301.0", TileVersion: "0.300.0"},
+		},
+	}
+
+	var parsed JSONReport
+	if err := json.Unmarshal([]byte(GenerateJSONReport(categorized, testHeader)), &parsed); err != nil {
+		t.Fatalf("Invalid JSON: %v", err)
+	}
+
+	expectedChange := JSONComponentChange{Name: "gorouter", OldVersion: "0.300.0", NewVersion: "0.301.0"}
+	if len(parsed.ReleaseNotesComponents) != 1 || parsed.ReleaseNotesComponents[0] != expectedChange {
+		t.Errorf("Expected %+v, got %+v", expectedChange, parsed.ReleaseNotesComponents)
+	}
+
+	expectedMismatch := JSONComponentMismatch{Name: "gorouter", Release: "routing", NotesVersion: "0.301.0", TileVersion: "0.300.0"}
+	if len(parsed.ComponentMismatches) != 1 || parsed.ComponentMismatches[0] != expectedMismatch {
+		t.Errorf("Expected %+v, got %+v", expectedMismatch, parsed.ComponentMismatches)
+	}
+}
@@ -0,0 +1,45 @@
+// ABOUTME: Checks stemcell availability in Ops Manager against tile requirements.
+// ABOUTME: Produces required actions for stemcells that must be uploaded before upgrading.
+package report
+
+import (
+	"fmt"
+
+	"github.com/malston/tile-diff/pkg/api"
+	"github.com/malston/tile-diff/pkg/compare"
+	"github.com/malston/tile-diff/pkg/metadata"
+)
+
+// CheckStemcellAvailability returns required actions for stemcells the new tile needs
+// that have not been uploaded to the Ops Manager stemcell library
+func CheckStemcellAvailability(required []metadata.StemcellCriteria, library []api.StemcellLibraryEntry) []CategorizedChange {
+	var actions []CategorizedChange
+
+	for _, criteria := range required {
+		if hasCompatibleStemcell(criteria, library) {
+			continue
+		}
+
+		actions = append(actions, CategorizedChange{
+			ComparisonResult: compare.ComparisonResult{
+				PropertyName: fmt.Sprintf("stemcell %s", criteria.OS),
+				ChangeType:   compare.StemcellMissing,
+				Description:  fmt.Sprintf("No compatible %s stemcell (version %s or later) uploaded to Ops Manager", criteria.OS, criteria.Version),
+			},
+			Category:       CategoryRequired,
+			Recommendation: fmt.Sprintf("Upload a %s stemcell at version %s or later before upgrading", criteria.OS, criteria.Version),
+		})
+	}
+
+	return actions
+}
+
+// hasCompatibleStemcell reports whether any uploaded stemcell satisfies the criteria
+func hasCompatibleStemcell(criteria metadata.StemcellCriteria, library []api.StemcellLibraryEntry) bool {
+	for _, stemcell := range library {
+		if compare.IsStemcellCompatible(criteria, stemcell.OS, stemcell.Version) {
+			return true
+		}
+	}
+	return false
+}
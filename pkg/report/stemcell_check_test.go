@@ -0,0 +1,51 @@
+// ABOUTME: Unit tests for Ops Manager stemcell availability checks.
+// ABOUTME: Verifies missing stemcells become required actions.
+package report
+
+import (
+	"testing"
+
+	"github.com/malston/tile-diff/pkg/api"
+	"github.com/malston/tile-diff/pkg/compare"
+	"github.com/malston/tile-diff/pkg/metadata"
+)
+
+func TestCheckStemcellAvailability(t *testing.T) {
+	required := []metadata.StemcellCriteria{
+		{OS: "ubuntu-jammy", Version: "1.500"},
+		{OS: "windows2019", Version: "2019.70"},
+	}
+	library := []api.StemcellLibraryEntry{
+		{OS: "ubuntu-jammy", Version: "1.512"},
+		{OS: "windows2019", Version: "2019.60"},
+	}
+
+	actions := CheckStemcellAvailability(required, library)
+
+	if len(actions) != 1 {
+		t.Fatalf("Expected 1 required action, got %d", len(actions))
+	}
+	if actions[0].PropertyName != "stemcell windows2019" {
+		t.Errorf("Expected 'stemcell windows2019', got '%s'", actions[0].PropertyName)
+	}
+	if actions[0].ChangeType != compare.StemcellMissing {
+		t.Errorf("Expected ChangeType StemcellMissing, got %s", actions[0].ChangeType)
+	}
+	if actions[0].Category != CategoryRequired {
+		t.Errorf("Expected CategoryRequired, got %s", actions[0].Category)
+	}
+}
+
+func TestCheckStemcellAvailability_AllUploaded(t *testing.T) {
+	required := []metadata.StemcellCriteria{
+		{OS: "ubuntu-jammy", Version: "1.500"},
+	}
+	library := []api.StemcellLibraryEntry{
+		{OS: "ubuntu-jammy", Version: "1.500"},
+	}
+
+	actions := CheckStemcellAvailability(required, library)
+	if len(actions) != 0 {
+		t.Errorf("Expected no required actions, got %d", len(actions))
+	}
+}
@@ -4,46 +4,43 @@ package report
 
 import (
 	"fmt"
+	"sort"
 	"strings"
+
+	"github.com/malston/tile-diff/pkg/compare"
+	"github.com/malston/tile-diff/pkg/releasenotes"
 )
 
 const separator = "================================================================================\n"
 
 // GenerateTextReport creates a formatted text report from categorized changes
-func GenerateTextReport(categorized *CategorizedChanges, oldVersion, newVersion string) string {
+func GenerateTextReport(categorized *CategorizedChanges, header ReportHeader) string {
 	var sb strings.Builder
 
-	writeHeader(&sb, oldVersion, newVersion)
+	writeHeader(&sb, header)
 	writeSummary(&sb, categorized)
 
-	// Write required actions
-	if len(categorized.RequiredActions) > 0 {
-		sb.WriteString("\n")
-		sb.WriteString(separator)
-		sb.WriteString("🚨 REQUIRED ACTIONS\n")
-		sb.WriteString(separator)
-		sb.WriteString("\n")
-		sb.WriteString("These changes MUST be addressed before upgrading:\n\n")
-
-		for i, change := range categorized.RequiredActions {
-			sb.WriteString(fmt.Sprintf("%d. %s\n", i+1, change.PropertyName))
-			sb.WriteString(fmt.Sprintf("   Type: %s\n", change.NewProperty.Type))
-			sb.WriteString(fmt.Sprintf("   Action: %s\n", change.Recommendation))
-			sb.WriteString("\n")
-		}
-	}
-
-	writeWarnings(&sb, categorized.Warnings)
+	writeRequiredActions(&sb, categorized.RequiredActions)
+	writeWarnings(&sb, categorized.Warnings, nil)
 	writeInformational(&sb, categorized.Informational)
+	writeStemcells(&sb, categorized.Stemcells)
+	writeComponentVersions(&sb, categorized.Releases)
+	writeDependencies(&sb, categorized.Dependencies)
+	writeErrands(&sb, categorized.Errands)
+	writeForms(&sb, categorized.Forms)
+	writeCredentials(&sb, categorized.Variables)
+	writeUpgradeNotes(&sb, categorized.UpgradeNotes)
+	writeSecurityFixes(&sb, categorized.SecurityFixes)
+	writeNotesComponents(&sb, categorized.NotesComponents, categorized.ComponentMismatches)
 
 	return sb.String()
 }
 
 // GenerateTextReportWithFeatures generates a text report with feature grouping
-func GenerateTextReportWithFeatures(enriched *EnrichedChanges, oldVersion, newVersion string) string {
+func GenerateTextReportWithFeatures(enriched *EnrichedChanges, header ReportHeader) string {
 	var sb strings.Builder
 
-	writeHeader(&sb, oldVersion, newVersion)
+	writeHeader(&sb, header)
 	writeSummary(&sb, enriched.CategorizedChanges)
 
 	// Write required actions with feature grouping
@@ -59,9 +56,11 @@ func GenerateTextReportWithFeatures(enriched *EnrichedChanges, oldVersion, newVe
 		featureProps := buildFeaturePropertyMap(enriched)
 		ungrouped := findUngroupedProperties(enriched.RequiredActions, featureProps)
 
-		// Write feature groups first
+		// Write feature groups first, skipping features matched only by warnings or informational changes
 		for _, feature := range enriched.Features {
-			writeFeatureGroup(&sb, feature, enriched.RequiredActions)
+			if featureHasChanges(feature, enriched.RequiredActions) {
+				writeFeatureGroup(&sb, feature, enriched.RequiredActions)
+			}
 		}
 
 		// Write ungrouped properties
@@ -73,20 +72,49 @@ func GenerateTextReportWithFeatures(enriched *EnrichedChanges, oldVersion, newVe
 		}
 	}
 
-	// Write warnings and informational (existing logic)
-	writeWarnings(&sb, enriched.Warnings)
+	// Write warnings with the release note that explains each, and informational (existing logic)
+	writeWarnings(&sb, enriched.Warnings, enriched.Matches)
 	writeInformational(&sb, enriched.Informational)
+	writeStemcells(&sb, enriched.Stemcells)
+	writeComponentVersions(&sb, enriched.Releases)
+	writeDependencies(&sb, enriched.Dependencies)
+	writeErrands(&sb, enriched.Errands)
+	writeForms(&sb, enriched.Forms)
+	writeCredentials(&sb, enriched.Variables)
+	writeUpgradeNotes(&sb, enriched.UpgradeNotes)
+	writeSecurityFixes(&sb, enriched.SecurityFixes)
+	writeNotesComponents(&sb, enriched.NotesComponents, enriched.ComponentMismatches)
 
 	return sb.String()
 }
 
-func writeHeader(sb *strings.Builder, oldVersion, newVersion string) {
+func writeHeader(sb *strings.Builder, header ReportHeader) {
 	sb.WriteString(separator)
 	sb.WriteString("                  Ops Manager Tile Upgrade Analysis\n")
 	sb.WriteString(separator)
 	sb.WriteString("\n")
-	sb.WriteString(fmt.Sprintf("Old Version: %s\n", oldVersion))
-	sb.WriteString(fmt.Sprintf("New Version: %s\n\n", newVersion))
+
+	switch {
+	case header.Old.ProductName == header.New.ProductName && header.New.ProductName != "":
+		sb.WriteString(fmt.Sprintf("Product:     %s\n", header.New.ProductName))
+	case header.Old.ProductName != "" || header.New.ProductName != "":
+		sb.WriteString(fmt.Sprintf("Product:     %s -> %s\n", header.Old.ProductName, header.New.ProductName))
+	}
+
+	writeTileInfo(sb, "Old", header.Old)
+	writeTileInfo(sb, "New", header.New)
+	sb.WriteString("\n")
+}
+
+// writeTileInfo writes the version line for one tile, with its file and checksum when known
+func writeTileInfo(sb *strings.Builder, side string, tile TileInfo) {
+	sb.WriteString(fmt.Sprintf("%s Version: %s\n", side, tile.Label()))
+	if tile.FileName != "" && tile.FileName != tile.Label() {
+		sb.WriteString(fmt.Sprintf("  File:      %s\n", tile.FileName))
+	}
+	if tile.SHA256 != "" {
+		sb.WriteString(fmt.Sprintf("  SHA256:    %s\n", tile.SHA256))
+	}
 }
 
 func writeSummary(sb *strings.Builder, changes *CategorizedChanges) {
@@ -97,7 +125,30 @@ func writeSummary(sb *strings.Builder, changes *CategorizedChanges) {
 	sb.WriteString(fmt.Sprintf("  Informational: %d\n\n", len(changes.Informational)))
 }
 
-func writeWarnings(sb *strings.Builder, warnings []CategorizedChange) {
+func writeRequiredActions(sb *strings.Builder, actions []CategorizedChange) {
+	if len(actions) > 0 {
+		sb.WriteString("\n")
+		sb.WriteString(separator)
+		sb.WriteString("🚨 REQUIRED ACTIONS\n")
+		sb.WriteString(separator)
+		sb.WriteString("\n")
+		sb.WriteString("These changes MUST be addressed before upgrading:\n\n")
+
+		writeGroupedByForm(sb, actions, func(i int, change CategorizedChange) {
+			sb.WriteString(fmt.Sprintf("%d. %s\n", i, displayName(change)))
+			if change.NewProperty != nil {
+				sb.WriteString(fmt.Sprintf("   Type: %s\n", change.NewProperty.Type))
+			} else {
+				sb.WriteString(fmt.Sprintf("   Change: %s\n", change.Description))
+			}
+			sb.WriteString(fmt.Sprintf("   Action: %s\n", change.Recommendation))
+			sb.WriteString("\n")
+		})
+	}
+}
+
+// writeWarnings lists warnings; with matches, each is followed by the release note that explains it
+func writeWarnings(sb *strings.Builder, warnings []CategorizedChange, matches map[string]releasenotes.Match) {
 	if len(warnings) > 0 {
 		sb.WriteString("\n")
 		sb.WriteString(separator)
@@ -106,13 +157,41 @@ func writeWarnings(sb *strings.Builder, warnings []CategorizedChange) {
 		sb.WriteString("\n")
 		sb.WriteString("These changes should be reviewed:\n\n")
 
-		for i, change := range warnings {
-			sb.WriteString(fmt.Sprintf("%d. %s\n", i+1, change.PropertyName))
+		writeGroupedByForm(sb, warnings, func(i int, change CategorizedChange) {
+			sb.WriteString(fmt.Sprintf("%d. %s\n", i, displayName(change)))
 			sb.WriteString(fmt.Sprintf("   Change: %s\n", change.Description))
 			sb.WriteString(fmt.Sprintf("   Recommendation: %s\n", change.Recommendation))
+			if match, ok := matches[change.PropertyName]; ok {
+				writeReleaseNoteMatch(sb, match)
+			}
 			sb.WriteString("\n")
+		})
+	}
+}
+
+// maxNoteLength limits how much of a matched release note is quoted under a change
+const maxNoteLength = 200
+
+// writeReleaseNoteMatch quotes the release note matched to a change and why it matched
+func writeReleaseNoteMatch(sb *strings.Builder, match releasenotes.Match) {
+	// Flatten the cleaned description onto one line, dropping its bullet markers
+	var words []string
+	for _, word := range strings.Fields(CleanDescription(match.Feature.Description)) {
+		if word != "•" {
+			words = append(words, word)
 		}
 	}
+	note := strings.Join(words, " ")
+	if runes := []rune(note); len(runes) > maxNoteLength {
+		note = strings.TrimSpace(string(runes[:maxNoteLength])) + "..."
+	}
+
+	source := match.Feature.Title
+	if match.Feature.Version != "" && match.Feature.Version != match.Feature.Title {
+		source = fmt.Sprintf("%s, %s", match.Feature.Version, source)
+	}
+	sb.WriteString(fmt.Sprintf("   Release Note (%s): %s\n", source, note))
+	sb.WriteString(fmt.Sprintf("   Why: %s\n", match.Explanation))
 }
 
 func writeInformational(sb *strings.Builder, informational []CategorizedChange) {
@@ -124,8 +203,8 @@ func writeInformational(sb *strings.Builder, informational []CategorizedChange)
 		sb.WriteString("\n")
 		sb.WriteString("New optional features available:\n\n")
 
-		for i, change := range informational {
-			sb.WriteString(fmt.Sprintf("%d. %s\n", i+1, change.PropertyName))
+		writeGroupedByForm(sb, informational, func(i int, change CategorizedChange) {
+			sb.WriteString(fmt.Sprintf("%d. %s\n", i, displayName(change)))
 			if change.NewProperty != nil {
 				sb.WriteString(fmt.Sprintf("   Type: %s\n", change.NewProperty.Type))
 				if change.NewProperty.Default != nil {
@@ -134,10 +213,304 @@ func writeInformational(sb *strings.Builder, informational []CategorizedChange)
 			}
 			sb.WriteString(fmt.Sprintf("   Note: %s\n", change.Recommendation))
 			sb.WriteString("\n")
+		})
+	}
+}
+
+func writeStemcells(sb *strings.Builder, stemcells []compare.StemcellChange) {
+	if len(stemcells) > 0 {
+		sb.WriteString("\n")
+		sb.WriteString(separator)
+		sb.WriteString("💿 STEMCELL REQUIREMENTS\n")
+		sb.WriteString(separator)
+		sb.WriteString("\n")
+		sb.WriteString("Stemcell criteria changed between versions:\n\n")
+
+		for i, change := range stemcells {
+			sb.WriteString(fmt.Sprintf("%d. %s\n", i+1, change.OS))
+			sb.WriteString(fmt.Sprintf("   Change: %s\n", change.Description))
+			if change.ChangeType != compare.StemcellRemoved {
+				sb.WriteString(fmt.Sprintf("   Note: Ensure a %s stemcell at version %s or later is uploaded before upgrading\n", change.OS, change.NewVersion))
+			}
+			sb.WriteString("\n")
+		}
+	}
+}
+
+func writeComponentVersions(sb *strings.Builder, releases []compare.ReleaseChange) {
+	if len(releases) > 0 {
+		sb.WriteString("\n")
+		sb.WriteString(separator)
+		sb.WriteString("🧩 COMPONENT VERSIONS\n")
+		sb.WriteString(separator)
+		sb.WriteString("\n")
+		sb.WriteString("BOSH releases changed between versions:\n\n")
+
+		for _, change := range releases {
+			switch change.ChangeType {
+			case compare.ReleaseAdded:
+				sb.WriteString(fmt.Sprintf("  + %s %s", change.Name, change.NewVersion))
+			case compare.ReleaseRemoved:
+				sb.WriteString(fmt.Sprintf("  - %s %s", change.Name, change.OldVersion))
+			default:
+				sb.WriteString(fmt.Sprintf("  ~ %s %s -> %s", change.Name, change.OldVersion, change.NewVersion))
+			}
+			if change.FileMissing {
+				sb.WriteString(fmt.Sprintf(" (⚠️  %s not found in tile)", change.File))
+			}
+			sb.WriteString("\n")
+		}
+		sb.WriteString("\n")
+	}
+}
+
+func writeDependencies(sb *strings.Builder, dependencies []compare.DependencyChange) {
+	if len(dependencies) > 0 {
+		sb.WriteString("\n")
+		sb.WriteString(separator)
+		sb.WriteString("🔗 PRODUCT DEPENDENCIES\n")
+		sb.WriteString(separator)
+		sb.WriteString("\n")
+		sb.WriteString("Required product versions changed between versions:\n\n")
+
+		for i, change := range dependencies {
+			sb.WriteString(fmt.Sprintf("%d. %s\n", i+1, change.Name))
+			sb.WriteString(fmt.Sprintf("   Change: %s\n", change.Description))
+			sb.WriteString("\n")
+		}
+	}
+}
+
+func writeErrands(sb *strings.Builder, errands []compare.ErrandChange) {
+	if len(errands) > 0 {
+		sb.WriteString("\n")
+		sb.WriteString(separator)
+		sb.WriteString("🛠️  ERRANDS\n")
+		sb.WriteString(separator)
+		sb.WriteString("\n")
+		sb.WriteString("Errands changed between versions:\n\n")
+
+		for _, change := range errands {
+			switch change.ChangeType {
+			case compare.ErrandAdded:
+				sb.WriteString(fmt.Sprintf("  + %s %s (default: %s)\n", change.Lifecycle, change.Name, change.NewDefault))
+			case compare.ErrandRemoved:
+				sb.WriteString(fmt.Sprintf("  - %s %s\n", change.Lifecycle, change.Name))
+			default:
+				sb.WriteString(fmt.Sprintf("  ~ %s %s (default: %s -> %s)\n", change.Lifecycle, change.Name, change.OldDefault, change.NewDefault))
+			}
+		}
+		sb.WriteString("\n")
+	}
+}
+
+func writeForms(sb *strings.Builder, forms []compare.FormChange) {
+	if len(forms) > 0 {
+		sb.WriteString("\n")
+		sb.WriteString(separator)
+		sb.WriteString("🗂️  OPS MANAGER TABS\n")
+		sb.WriteString(separator)
+		sb.WriteString("\n")
+		sb.WriteString("Configuration tabs changed between versions:\n\n")
+
+		for _, change := range forms {
+			if change.ChangeType == compare.FormAdded {
+				sb.WriteString(fmt.Sprintf("  + %s (%s)\n", change.Label, change.Name))
+			} else {
+				sb.WriteString(fmt.Sprintf("  - %s (%s)\n", change.Label, change.Name))
+			}
+		}
+		sb.WriteString("\n")
+	}
+}
+
+func writeCredentials(sb *strings.Builder, variables []compare.VariableChange) {
+	if len(variables) > 0 {
+		sb.WriteString("\n")
+		sb.WriteString(separator)
+		sb.WriteString("🔐 CREDENTIALS & CERTIFICATES\n")
+		sb.WriteString(separator)
+		sb.WriteString("\n")
+		sb.WriteString("Generated credentials changed between versions:\n\n")
+
+		for i, change := range variables {
+			sb.WriteString(fmt.Sprintf("%d. %s (%s)\n", i+1, change.Name, change.Type))
+			sb.WriteString(fmt.Sprintf("   Change: %s\n", change.Description))
+			if change.RotationRisk {
+				sb.WriteString("   Note: ⚠️  May trigger credential rotation; plan for certificate redeployment\n")
+			}
+			sb.WriteString("\n")
+		}
+	}
+}
+
+// upgradeNoteHeadings names each release notes section kind in the upgrade notes section
+var upgradeNoteHeadings = map[releasenotes.SectionKind]string{
+	releasenotes.SectionBreakingChanges: "Breaking Changes",
+	releasenotes.SectionDeprecations:    "Deprecations",
+	releasenotes.SectionKnownIssues:     "Known Issues",
+}
+
+func writeUpgradeNotes(sb *strings.Builder, notes []releasenotes.Note) {
+	if len(notes) == 0 {
+		return
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(separator)
+	sb.WriteString("📰 RELEASE NOTES: BREAKING CHANGES & KNOWN ISSUES\n")
+	sb.WriteString(separator)
+	sb.WriteString("\n")
+	sb.WriteString("From releases included in this upgrade:\n")
+
+	for _, kind := range UpgradeNoteKinds {
+		var ofKind []releasenotes.Note
+		for _, note := range notes {
+			if note.Kind == kind {
+				ofKind = append(ofKind, note)
+			}
+		}
+		if len(ofKind) == 0 {
+			continue
+		}
+
+		sb.WriteString(fmt.Sprintf("\n%s:\n", upgradeNoteHeadings[kind]))
+		for _, note := range ofKind {
+			text := note.Text
+			if note.Title != "" && text != "" {
+				text = note.Title + ": " + text
+			} else if note.Title != "" {
+				text = note.Title
+			}
+			sb.WriteString(fmt.Sprintf("  [%s] %s\n", note.Version, text))
+		}
+	}
+	sb.WriteString("\n")
+}
+
+func writeSecurityFixes(sb *strings.Builder, fixes []releasenotes.SecurityFix) {
+	if len(fixes) == 0 {
+		return
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(separator)
+	sb.WriteString("🛡️  SECURITY FIXES\n")
+	sb.WriteString(separator)
+	sb.WriteString("\n")
+	sb.WriteString(fmt.Sprintf("%d security advisories resolved by this upgrade:\n\n", len(fixes)))
+
+	for _, fix := range fixes {
+		var context []string
+		for _, field := range []string{fix.Version, fix.Component} {
+			if field != "" {
+				context = append(context, field)
+			}
+		}
+		if len(context) == 0 {
+			sb.WriteString(fmt.Sprintf("  %s\n", fix.ID))
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("  %s (%s)\n", fix.ID, strings.Join(context, ", ")))
+	}
+	sb.WriteString("\n")
+}
+
+func writeNotesComponents(sb *strings.Builder, changes []releasenotes.ComponentChange, mismatches []releasenotes.ComponentMismatch) {
+	if len(changes) == 0 && len(mismatches) == 0 {
+		return
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(separator)
+	sb.WriteString("📋 RELEASE NOTES: COMPONENT VERSIONS\n")
+	sb.WriteString(separator)
+	sb.WriteString("\n")
+
+	if len(changes) > 0 {
+		sb.WriteString("Components changed according to release notes:\n\n")
+		for _, change := range changes {
+			switch {
+			case change.OldVersion == "":
+				sb.WriteString(fmt.Sprintf("  + %s %s\n", change.Name, change.NewVersion))
+			case change.NewVersion == "":
+				sb.WriteString(fmt.Sprintf("  - %s %s\n", change.Name, change.OldVersion))
+			default:
+				sb.WriteString(fmt.Sprintf("  ~ %s %s -> %s\n", change.Name, change.OldVersion, change.NewVersion))
+			}
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(mismatches) > 0 {
+		sb.WriteString("Release notes disagree with the BOSH releases bundled in the tile:\n\n")
+		for _, mismatch := range mismatches {
+			sb.WriteString(fmt.Sprintf("  ⚠️  %s: release notes list %s, tile bundles %s %s\n",
+				mismatch.Name, mismatch.NotesVersion, mismatch.Release, mismatch.TileVersion))
+		}
+		sb.WriteString("\n")
+	}
+}
+
+// writeGroupedByForm writes changes under their Ops Manager tab, numbering them across tabs;
+// tab headings are omitted when no change is placed on a form
+func writeGroupedByForm(sb *strings.Builder, changes []CategorizedChange, writeChange func(int, CategorizedChange)) {
+	groups := groupByForm(changes)
+	n := 0
+	for _, group := range groups {
+		if len(groups) > 1 || group.label != "" {
+			sb.WriteString(fmt.Sprintf("-- Tab: %s --\n\n", group.label))
+		}
+		for _, change := range group.changes {
+			n++
+			writeChange(n, change)
 		}
 	}
 }
 
+type formGroup struct {
+	label   string
+	changes []CategorizedChange
+}
+
+// groupByForm groups changes by form label in alphabetical order, with unplaced changes last
+func groupByForm(changes []CategorizedChange) []formGroup {
+	var groups []formGroup
+	index := make(map[string]int)
+	for _, change := range changes {
+		i, ok := index[change.FormLabel]
+		if !ok {
+			i = len(groups)
+			index[change.FormLabel] = i
+			groups = append(groups, formGroup{label: change.FormLabel})
+		}
+		groups[i].changes = append(groups[i].changes, change)
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		if (groups[i].label == "") != (groups[j].label == "") {
+			return groups[j].label == ""
+		}
+		return groups[i].label < groups[j].label
+	})
+
+	for i := range groups {
+		if groups[i].label == "" && len(groups) > 1 {
+			groups[i].label = "Other"
+		}
+	}
+
+	return groups
+}
+
+// displayName returns the property path followed by its form label when known
+func displayName(change CategorizedChange) string {
+	if change.PropertyLabel != "" {
+		return fmt.Sprintf("%s (%s)", change.PropertyName, change.PropertyLabel)
+	}
+	return change.PropertyName
+}
+
 func buildFeaturePropertyMap(enriched *EnrichedChanges) map[string]string {
 	featureProps := make(map[string]string)
 	for _, feature := range enriched.Features {
@@ -148,6 +521,18 @@ func buildFeaturePropertyMap(enriched *EnrichedChanges) map[string]string {
 	return featureProps
 }
 
+// featureHasChanges reports whether any of the feature's properties appear in changes
+func featureHasChanges(feature FeatureGroup, changes []CategorizedChange) bool {
+	for _, prop := range feature.Properties {
+		for _, change := range changes {
+			if change.PropertyName == prop {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func findUngroupedProperties(changes []CategorizedChange, featureProps map[string]string) []CategorizedChange {
 	var ungrouped []CategorizedChange
 	for _, change := range changes {
@@ -159,7 +544,11 @@ func findUngroupedProperties(changes []CategorizedChange, featureProps map[strin
 }
 
 func writeFeatureGroup(sb *strings.Builder, feature FeatureGroup, changes []CategorizedChange) {
-	sb.WriteString(fmt.Sprintf("📦 %s (%d properties)\n", feature.Name, len(feature.Properties)))
+	if feature.Version != "" && feature.Version != feature.Name {
+		sb.WriteString(fmt.Sprintf("📦 %s (%d properties, introduced in %s)\n", feature.Name, len(feature.Properties), feature.Version))
+	} else {
+		sb.WriteString(fmt.Sprintf("📦 %s (%d properties)\n", feature.Name, len(feature.Properties)))
+	}
 
 	// Clean and format the description
 	cleanedDesc := CleanDescription(feature.Description)
@@ -188,7 +577,10 @@ func writeFeatureGroup(sb *strings.Builder, feature FeatureGroup, changes []Cate
 
 func writePropertyDetail(sb *strings.Builder, change CategorizedChange, indent int) {
 	indentStr := strings.Repeat(" ", indent)
-	sb.WriteString(fmt.Sprintf("%s• %s\n", indentStr, change.PropertyName))
+	sb.WriteString(fmt.Sprintf("%s• %s\n", indentStr, displayName(change)))
+	if change.FormLabel != "" {
+		sb.WriteString(fmt.Sprintf("%s  Tab: %s\n", indentStr, change.FormLabel))
+	}
 	if change.NewProperty != nil {
 		sb.WriteString(fmt.Sprintf("%s  Type: %s\n", indentStr, change.NewProperty.Type))
 	}
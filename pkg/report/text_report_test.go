@@ -5,9 +5,12 @@ package report
 import (
 	"strings"
 	"testing"
+	"testing/quick"
 
+	"github.com/malston/tile-diff/internal/tiletest"
 	"github.com/malston/tile-diff/pkg/compare"
 	"github.com/malston/tile-diff/pkg/metadata"
+	"github.com/malston/tile-diff/pkg/releasenotes"
 )
 
 func TestGenerateTextReport(t *testing.T) {
@@ -49,7 +52,7 @@ func TestGenerateTextReport(t *testing.T) {
 		},
 	}
 
-	report := GenerateTextReport(categorized, "6.0.22", "10.2.5")
+	report := GenerateTextReport(categorized, testHeader)
 
 	// Check for header
 	if !strings.Contains(report, "Upgrade Analysis") {
@@ -142,7 +145,7 @@ func TestGenerateTextReport_ShowsDefaultValues(t *testing.T) {
 		},
 	}
 
-	report := GenerateTextReport(categorized, "6.0.22", "10.2.5")
+	report := GenerateTextReport(categorized, testHeader)
 
 	// Check that default values are shown
 	if !strings.Contains(report, "Default: true") {
@@ -191,9 +194,371 @@ func TestGenerateTextReport_WithFeatures(t *testing.T) {
 		},
 	}
 
-	report := GenerateTextReportWithFeatures(enriched, "6.0.22", "10.2.5")
+	report := GenerateTextReportWithFeatures(enriched, testHeader)
 
 	if !strings.Contains(report, "📦 Enhanced Security") {
 		t.Error("Expected report to contain feature grouping")
 	}
 }
+
+func TestGenerateTextReport_WithFeatures_WarningReleaseNotes(t *testing.T) {
+	deprecation := releasenotes.Feature{
+		Title:       "Deprecations",
+		Description: "The legacy_syslog_drain property is deprecated and will be removed.",
+		Version:     "10.2.4",
+		Kind:        releasenotes.SectionDeprecations,
+	}
+	changes := &CategorizedChanges{
+		RequiredActions: []CategorizedChange{
+			{ComparisonResult: compare.ComparisonResult{PropertyName: "new_required"}, Category: CategoryRequired, Recommendation: "Set a value"},
+		},
+		Warnings: []CategorizedChange{
+			{
+				ComparisonResult: compare.ComparisonResult{PropertyName: "legacy_syslog_drain", ChangeType: compare.PropertyRemoved, Description: "Property removed"},
+				Category:         CategoryWarning,
+				Recommendation:   "Remove from your configuration",
+			},
+		},
+	}
+	matches := map[string]releasenotes.Match{
+		"legacy_syslog_drain": {Property: "legacy_syslog_drain", Feature: deprecation, MatchType: "direct", Confidence: 1.0, Explanation: "release note names legacy_syslog_drain"},
+	}
+
+	report := GenerateTextReportWithFeatures(EnrichChanges(changes, matches), testHeader)
+
+	expected := "   Recommendation: Remove from your configuration\n" +
+		"   Release Note (10.2.4, Deprecations): The legacy_syslog_drain property is deprecated and will be removed.\n" +
+		"   Why: release note names legacy_syslog_drain\n"
+	if !strings.Contains(report, expected) {
+		t.Errorf("Expected warning with its release note:\n%s\ngot:\n%s", expected, report)
+	}
+
+	// A feature matched only by a warning is not listed under required actions
+	if strings.Contains(report, "📦 Deprecations") {
+		t.Errorf("Expected no feature group for a warning-only feature, got:\n%s", report)
+	}
+
+	if strings.Contains(GenerateTextReport(changes, testHeader), "Release Note (") {
+		t.Error("Expected no release notes in the report without matches")
+	}
+}
+
+func TestGenerateTextReport_Stemcells(t *testing.T) {
+	categorized := &CategorizedChanges{
+		RequiredActions: []CategorizedChange{
+			{
+				ComparisonResult: compare.ComparisonResult{
+					PropertyName: "stemcell ubuntu-jammy",
+					ChangeType:   compare.StemcellMissing,
+					Description:  "No compatible ubuntu-jammy stemcell (version 1.500 or later) uploaded to Ops Manager",
+				},
+				Category:       CategoryRequired,
+				Recommendation: "Upload a ubuntu-jammy stemcell at version 1.500 or later before upgrading",
+			},
+		},
+		Stemcells: []compare.StemcellChange{
+			{
+				OS:          "ubuntu-jammy",
+				ChangeType:  compare.StemcellChanged,
+				OldVersion:  "1.456",
+				NewVersion:  "1.500",
+				Description: "Stemcell version changed from 1.456 to 1.500",
+			},
+		},
+	}
+
+	report := GenerateTextReport(categorized, testHeader)
+
+	if !strings.Contains(report, "STEMCELL REQUIREMENTS") {
+		t.Error("Expected 'STEMCELL REQUIREMENTS' section")
+	}
+	if !strings.Contains(report, "Stemcell version changed from 1.456 to 1.500") {
+		t.Error("Expected stemcell change description in report")
+	}
+	if !strings.Contains(report, "Change: No compatible ubuntu-jammy stemcell") {
+		t.Error("Expected missing stemcell required action to show its description")
+	}
+}
+
+func TestGenerateTextReport_ComponentVersions(t *testing.T) {
+	categorized := &CategorizedChanges{
+		Releases: []compare.ReleaseChange{
+			{Name: "capi", ChangeType: compare.ReleaseUpdated, OldVersion: "1.2.3", NewVersion: "1.5.0", File: "capi-1.5.0.tgz"},
+			{Name: "consul", ChangeType: compare.ReleaseRemoved, OldVersion: "1.0.0"},
+			{Name: "routing", ChangeType: compare.ReleaseAdded, NewVersion: "0.300.0", File: "routing-0.300.0.tgz", FileMissing: true},
+		},
+	}
+
+	report := GenerateTextReport(categorized, testHeader)
+
+	if !strings.Contains(report, "COMPONENT VERSIONS") {
+		t.Error("Expected 'COMPONENT VERSIONS' section")
+	}
+	if !strings.Contains(report, "~ capi 1.2.3 -> 1.5.0") {
+		t.Error("Expected capi version bump in report")
+	}
+	if !strings.Contains(report, "- consul 1.0.0") {
+		t.Error("Expected consul removal in report")
+	}
+	if !strings.Contains(report, "routing-0.300.0.tgz not found in tile") {
+		t.Error("Expected missing release file warning in report")
+	}
+}
+
+func TestGenerateTextReport_Dependencies(t *testing.T) {
+	categorized := &CategorizedChanges{
+		Dependencies: []compare.DependencyChange{
+			{Name: "p-bosh", ChangeType: compare.DependencyChanged, OldConstraint: "~> 2.10", NewConstraint: "~> 3.0", Description: "Required version changed from ~> 2.10 to ~> 3.0"},
+		},
+	}
+
+	report := GenerateTextReport(categorized, testHeader)
+
+	if !strings.Contains(report, "PRODUCT DEPENDENCIES") {
+		t.Error("Expected 'PRODUCT DEPENDENCIES' section")
+	}
+	if !strings.Contains(report, "1. p-bosh") {
+		t.Error("Expected p-bosh dependency in report")
+	}
+	if !strings.Contains(report, "~> 2.10 to ~> 3.0") {
+		t.Error("Expected constraint change description in report")
+	}
+}
+
+func TestGenerateTextReport_Errands(t *testing.T) {
+	categorized := &CategorizedChanges{
+		Errands: []compare.ErrandChange{
+			{Name: "push-usage-service", Lifecycle: compare.PostDeployErrand, ChangeType: compare.ErrandChanged, OldDefault: "off", NewDefault: "on"},
+			{Name: "rotate_cc_database_key", Lifecycle: compare.PostDeployErrand, ChangeType: compare.ErrandAdded, NewDefault: "when-changed"},
+			{Name: "delete-apps-manager", Lifecycle: compare.PreDeleteErrand, ChangeType: compare.ErrandRemoved, OldDefault: "on"},
+		},
+	}
+
+	report := GenerateTextReport(categorized, testHeader)
+
+	if !strings.Contains(report, "ERRANDS") {
+		t.Error("Expected 'ERRANDS' section")
+	}
+	if !strings.Contains(report, "~ post-deploy push-usage-service (default: off -> on)") {
+		t.Error("Expected push-usage-service default change in report")
+	}
+	if !strings.Contains(report, "+ post-deploy rotate_cc_database_key (default: when-changed)") {
+		t.Error("Expected new errand in report")
+	}
+	if !strings.Contains(report, "- pre-delete delete-apps-manager") {
+		t.Error("Expected removed errand in report")
+	}
+}
+
+func TestGenerateTextReport_GroupsByFormTab(t *testing.T) {
+	categorized := &CategorizedChanges{
+		Warnings: []CategorizedChange{
+			{
+				ComparisonResult: compare.ComparisonResult{PropertyName: "unlabelled_prop", ChangeType: compare.PropertyRemoved},
+				Category:         CategoryWarning,
+			},
+			{
+				ComparisonResult: compare.ComparisonResult{PropertyName: "routing_tls_termination", ChangeType: compare.TypeChanged, FormName: "networking", FormLabel: "Networking", PropertyLabel: "TLS termination point"},
+				Category:         CategoryWarning,
+			},
+			{
+				ComparisonResult: compare.ComparisonResult{PropertyName: "credhub_hsm_provider", ChangeType: compare.PropertyRemoved, FormName: "credhub", FormLabel: "CredHub", PropertyLabel: "HSM provider"},
+				Category:         CategoryWarning,
+			},
+		},
+		Forms: []compare.FormChange{
+			{Name: "app_security", Label: "App Security", ChangeType: compare.FormAdded},
+		},
+	}
+
+	report := GenerateTextReport(categorized, testHeader)
+
+	credhub := strings.Index(report, "-- Tab: CredHub --")
+	networking := strings.Index(report, "-- Tab: Networking --")
+	other := strings.Index(report, "-- Tab: Other --")
+	if credhub < 0 || networking < 0 || other < 0 {
+		t.Fatalf("Expected CredHub, Networking and Other tab headings in report:\n%s", report)
+	}
+	if !(credhub < networking && networking < other) {
+		t.Error("Expected tabs in alphabetical order with unplaced changes last")
+	}
+	if !strings.Contains(report, "1. credhub_hsm_provider (HSM provider)") {
+		t.Error("Expected property label next to property path")
+	}
+	if !strings.Contains(report, "3. unlabelled_prop") {
+		t.Error("Expected numbering to continue across tabs")
+	}
+	if !strings.Contains(report, "+ App Security (app_security)") {
+		t.Error("Expected added form tab in report")
+	}
+}
+
+func TestGenerateTextReport_NoTabHeadingsWithoutForms(t *testing.T) {
+	categorized := &CategorizedChanges{
+		Warnings: []CategorizedChange{
+			{
+				ComparisonResult: compare.ComparisonResult{PropertyName: "removed_prop", ChangeType: compare.PropertyRemoved},
+				Category:         CategoryWarning,
+			},
+		},
+	}
+
+	report := GenerateTextReport(categorized, testHeader)
+
+	if strings.Contains(report, "-- Tab:") {
+		t.Error("Expected no tab headings when no change is placed on a form")
+	}
+}
+
+func TestGenerateTextReport_Credentials(t *testing.T) {
+	categorized := &CategorizedChanges{
+		Variables: []compare.VariableChange{
+			{Name: "loggregator-ca", Type: "certificate", ChangeType: compare.VariableAdded, RotationRisk: true, Description: "New certificate (CA) variable"},
+			{Name: "uaa-admin-password", Type: "password", ChangeType: compare.VariableChanged, Description: "Options changed: length"},
+		},
+	}
+
+	report := GenerateTextReport(categorized, testHeader)
+
+	if !strings.Contains(report, "CREDENTIALS & CERTIFICATES") {
+		t.Error("Expected 'CREDENTIALS & CERTIFICATES' section")
+	}
+	if !strings.Contains(report, "1. loggregator-ca (certificate)") {
+		t.Error("Expected loggregator-ca in report")
+	}
+	if strings.Count(report, "May trigger credential rotation") != 1 {
+		t.Error("Expected rotation note only for the rotation-risk change")
+	}
+}
+
+func TestReports_StableAcrossRuns(t *testing.T) {
+	stable := func(seed int64) bool {
+		oldTile, newTile := tiletest.RandomTilePair(seed)
+
+		render := func() (string, string) {
+			categorized := CategorizeChanges(compare.CompareMetadata(oldTile, newTile, true))
+			return GenerateTextReport(categorized, testHeader), GenerateJSONReport(categorized, testHeader)
+		}
+
+		firstText, firstJSON := render()
+		for run := 0; run < 10; run++ {
+			text, jsonReport := render()
+			if text != firstText || jsonReport != firstJSON {
+				return false
+			}
+		}
+		return true
+	}
+
+	if err := quick.Check(stable, &quick.Config{MaxCount: 20}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGenerateTextReport_Header(t *testing.T) {
+	header := ReportHeader{
+		Old: TileInfo{ProductName: "cf", Version: "6.0.22", FileName: "srt-6.0.22-build.2.pivotal", SHA256: "aaaa"},
+		New: TileInfo{ProductName: "cf", FileName: "srt-10.2.5-build.2.pivotal"},
+	}
+
+	report := GenerateTextReport(&CategorizedChanges{}, header)
+
+	expected := "Product:     cf\n" +
+		"Old Version: 6.0.22\n" +
+		"  File:      srt-6.0.22-build.2.pivotal\n" +
+		"  SHA256:    aaaa\n" +
+		"New Version: srt-10.2.5-build.2.pivotal\n\n"
+	if !strings.Contains(report, expected) {
+		t.Errorf("Expected header:\n%s\ngot:\n%s", expected, report)
+	}
+}
+
+func TestGenerateTextReport_FeatureVersion(t *testing.T) {
+	enriched := &EnrichedChanges{
+		CategorizedChanges: &CategorizedChanges{
+			RequiredActions: []CategorizedChange{
+				{ComparisonResult: compare.ComparisonResult{PropertyName: "router_timeout"}, Category: CategoryRequired},
+			},
+		},
+		Features: []FeatureGroup{
+			{Name: "Routing Improvements", Version: "6.0.23", Properties: []string{"router_timeout"}},
+		},
+	}
+
+	report := GenerateTextReportWithFeatures(enriched, testHeader)
+
+	if !strings.Contains(report, "📦 Routing Improvements (1 properties, introduced in 6.0.23)") {
+		t.Errorf("Expected feature heading with release, got:\n%s", report)
+	}
+}
+
+func TestGenerateTextReport_UpgradeNotes(t *testing.T) {
+	categorized := &CategorizedChanges{
+		UpgradeNotes: []releasenotes.Note{
+			{Version: "10.2.4", Kind: releasenotes.SectionDeprecations, Text: "The legacy_syslog_drain property is deprecated."},
+			{Version: "10.2.5", Kind: releasenotes.SectionBreakingChanges, Text: "TLS 1.1 is no longer accepted by the Gorouter."},
+			{Version: "10.2.5", Kind: releasenotes.SectionKnownIssues, Title: "Diego cells may restart", Text: "Upgrade Ops Manager first."},
+		},
+	}
+
+	report := GenerateTextReport(categorized, testHeader)
+
+	expected := "Breaking Changes:\n" +
+		"  [10.2.5] TLS 1.1 is no longer accepted by the Gorouter.\n" +
+		"\nDeprecations:\n" +
+		"  [10.2.4] The legacy_syslog_drain property is deprecated.\n" +
+		"\nKnown Issues:\n" +
+		"  [10.2.5] Diego cells may restart: Upgrade Ops Manager first.\n"
+	if !strings.Contains(report, "📰 RELEASE NOTES: BREAKING CHANGES & KNOWN ISSUES") || !strings.Contains(report, expected) {
+		t.Errorf("Expected upgrade notes section:\n%s\ngot:\n%s", expected, report)
+	}
+}
+
+func TestGenerateTextReport_SecurityFixes(t *testing.T) {
+	categorized := &CategorizedChanges{
+		SecurityFixes: []releasenotes.SecurityFix{
+			{ID: "CVE-2024-24790", Type: "CVE", Version: "10.2.4", Component: "golang"},
+			{ID: "USN-6543-1", Type: "USN", Version: "10.2.5"},
+			{ID: "CVE-2024-45337", Type: "CVE", Component: "gorouter"},
+			{ID: "GHSA-v778-237x-gjrc", Type: "GHSA"},
+		},
+	}
+
+	report := GenerateTextReport(categorized, testHeader)
+
+	expected := "4 security advisories resolved by this upgrade:\n\n" +
+		"  CVE-2024-24790 (10.2.4, golang)\n" +
+		"  USN-6543-1 (10.2.5)\n" +
+		"  CVE-2024-45337 (gorouter)\n" +
+		"  GHSA-v778-237x-gjrc\n"
+	if !strings.Contains(report, "🛡️  SECURITY FIXES") || !strings.Contains(report, expected) {
+		t.Errorf("Expected security fixes section:\n%s\ngot:\n%s", expected, report)
+	}
+
+	if strings.Contains(GenerateTextReport(&CategorizedChanges{}, testHeader), "SECURITY FIXES") {
+		t.Error("Expected no security fixes section when there are none")
+	}
+}
+
+func TestGenerateTextReport_NotesComponents(t *testing.T) {
+	categorized := &CategorizedChanges{
+		NotesComponents: []releasenotes.ComponentChange{
+			{Name: "capi", NewVersion: "1.180.0"},
+			{Name: "gorouter", OldVersion: "0.300.0", NewVersion: "0.301.0"},
+		},
+		ComponentMismatches: []releasenotes.ComponentMismatch{
+			{Name: "gorouter", Release: "routing", NotesVersion: "0.301.0", TileVersion: "0.300.0"},
+		},
+	}
+
+	report := GenerateTextReport(categorized, testHeader)
+
+	expected := "Components changed according to release notes:\n\n" +
+		"  + capi 1.180.0\n" +
+		"  ~ gorouter 0.300.0 -> 0.301.0\n" +
+		"\nRelease notes disagree with the BOSH releases bundled in the tile:\n\n" +
+		"  ⚠️  gorouter: release notes list 0.301.0, tile bundles routing 0.300.0\n"
+	if !strings.Contains(report, "📋 RELEASE NOTES: COMPONENT VERSIONS") || !strings.Contains(report, expected) {
+		t.Errorf("Expected release notes component section:\n%s\ngot:\n%s", expected, report)
+	}
+}
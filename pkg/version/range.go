@@ -0,0 +1,234 @@
+// ABOUTME: Parses version range expressions such as "10.2", "~10.2" and ">=6.0.20 <6.1".
+// ABOUTME: Partial versions match every release in their line, ignoring Pivnet suffixes like +LTS-T.
+package version
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Range selects versions by an expression. Each clause is a partial or full version ("10.2", "6.0.22"),
+// a wildcard ("10.x"), a tilde, caret or pessimistic range ("~10.2", "^6.0", "~> 2.10"), or a comparison
+// (">=6.0.20"). Clauses separated by spaces or commas must all hold; alternatives are separated by "||".
+type Range struct {
+	expr         string
+	alternatives [][]func(Version) bool
+	prerelease   bool // A clause names a pre-release version
+}
+
+// ParseRange parses a range expression
+func ParseRange(expr string) (Range, error) {
+	r := Range{expr: expr}
+	for _, alternative := range strings.Split(expr, "||") {
+		clauses, err := rangeClauses(alternative)
+		if err != nil {
+			return Range{}, err
+		}
+
+		var predicates []func(Version) bool
+		for _, clause := range clauses {
+			predicate, err := parseRangeClause(clause)
+			if err != nil {
+				return Range{}, fmt.Errorf("invalid version range %q: %w", expr, err)
+			}
+			predicates = append(predicates, predicate)
+			r.prerelease = r.prerelease || namesPrerelease(clause)
+		}
+		r.alternatives = append(r.alternatives, predicates)
+	}
+	return r, nil
+}
+
+// String returns the expression the range was parsed from
+func (r Range) String() string {
+	return r.expr
+}
+
+// NamesPrerelease reports whether the expression names a pre-release version such as 10.3.0-build.2
+func (r Range) NamesPrerelease() bool {
+	return r.prerelease
+}
+
+// Contains reports whether v is in the range
+func (r Range) Contains(v Version) bool {
+	for _, predicates := range r.alternatives {
+		matched := true
+		for _, predicate := range predicates {
+			if !predicate(v) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+// rangeOperators lists the comparison prefixes a clause may start with, longest first
+var rangeOperators = []string{"~>", ">=", "<=", "!=", ">", "<", "=", "~", "^"}
+
+// rangeClauses splits an alternative into clauses, joining operators written apart from their version
+func rangeClauses(alternative string) ([]string, error) {
+	fields := strings.Fields(strings.ReplaceAll(alternative, ",", " "))
+	if len(fields) == 0 {
+		return []string{"*"}, nil
+	}
+
+	var clauses []string
+	for i := 0; i < len(fields); i++ {
+		field := fields[i]
+		for _, op := range rangeOperators {
+			if field == op {
+				if i+1 == len(fields) {
+					return nil, fmt.Errorf("operator %s has no version", op)
+				}
+				i++
+				field += fields[i]
+				break
+			}
+		}
+		clauses = append(clauses, field)
+	}
+	return clauses, nil
+}
+
+// parseRangeClause returns a predicate for a single clause
+func parseRangeClause(clause string) (func(Version) bool, error) {
+	op := ""
+	for _, candidate := range rangeOperators {
+		if strings.HasPrefix(clause, candidate) {
+			op = candidate
+			clause = strings.TrimPrefix(clause, candidate)
+			break
+		}
+	}
+
+	if isWildcard(clause) {
+		if op != "" && op != "=" && op != ">=" && op != "<=" {
+			return nil, fmt.Errorf("%s%s matches nothing", op, clause)
+		}
+		return func(Version) bool { return true }, nil
+	}
+
+	target, partial, err := parsePartial(clause)
+	if err != nil {
+		return nil, err
+	}
+	lower := Version{Segments: target.Segments}
+	upper := nextLine(lower, partial)
+
+	switch op {
+	case "~>":
+		return between(lower, pessimisticUpperBound(lower)), nil
+	case "~":
+		if len(lower.Segments) >= 2 {
+			return between(lower, Version{Segments: []int{lower.Major(), lower.Minor() + 1}}), nil
+		}
+		return between(lower, Version{Segments: []int{lower.Major() + 1}}), nil
+	case "^":
+		return between(lower, caretUpperBound(lower)), nil
+	case ">=":
+		return func(v Version) bool { return core(v).Compare(lower) >= 0 }, nil
+	case ">":
+		if partial {
+			return func(v Version) bool { return core(v).Compare(upper) >= 0 }, nil
+		}
+		return func(v Version) bool { return core(v).Compare(lower) > 0 }, nil
+	case "<":
+		return func(v Version) bool { return core(v).Compare(lower) < 0 }, nil
+	case "<=":
+		if partial {
+			return func(v Version) bool { return core(v).Compare(upper) < 0 }, nil
+		}
+		return func(v Version) bool { return core(v).Compare(lower) <= 0 }, nil
+	case "!=":
+		equal := exactly(target, partial, lower, upper)
+		return func(v Version) bool { return !equal(v) }, nil
+	default:
+		return exactly(target, partial, lower, upper), nil
+	}
+}
+
+// namesPrerelease reports whether a clause's version carries a pre-release tag
+func namesPrerelease(clause string) bool {
+	for _, op := range rangeOperators {
+		if strings.HasPrefix(clause, op) {
+			clause = strings.TrimPrefix(clause, op)
+			break
+		}
+	}
+	target, _, err := parsePartial(clause)
+	return err == nil && target.Prerelease != ""
+}
+
+// exactly matches a version, its Pivnet builds such as +LTS-T when the target has no suffix, or any
+// version in the line of a partial target
+func exactly(target Version, partial bool, lower, upper Version) func(Version) bool {
+	if partial {
+		return between(lower, upper)
+	}
+	if target.Prerelease == "" && target.Build == "" {
+		return func(v Version) bool { return core(v).Compare(lower) == 0 }
+	}
+	return func(v Version) bool {
+		return v.Compare(target) == 0 && strings.EqualFold(v.Build, target.Build)
+	}
+}
+
+// between matches versions at or above lower and below upper, ignoring suffixes
+func between(lower, upper Version) func(Version) bool {
+	return func(v Version) bool {
+		c := core(v)
+		return c.Compare(lower) >= 0 && c.Compare(upper) < 0
+	}
+}
+
+// parsePartial parses a version that may omit segments or end in wildcards ("10", "10.2", "10.2.x"),
+// reporting whether it names a whole line rather than a single release
+func parsePartial(s string) (Version, bool, error) {
+	segments := strings.Split(s, ".")
+	for len(segments) > 1 && isWildcard(segments[len(segments)-1]) {
+		segments = segments[:len(segments)-1]
+	}
+
+	v, err := Parse(strings.Join(segments, "."))
+	if err != nil {
+		return Version{}, false, err
+	}
+	return v, len(v.Segments) < 3 && v.Prerelease == "" && v.Build == "", nil
+}
+
+// nextLine returns the first version after the line a partial version names (10.2 -> 10.3), or the
+// version itself for a full version
+func nextLine(v Version, partial bool) Version {
+	if !partial {
+		return v
+	}
+	segments := append([]int{}, v.Segments...)
+	segments[len(segments)-1]++
+	return Version{Segments: segments}
+}
+
+// caretUpperBound returns the exclusive upper bound for "^": the next version that changes the
+// leftmost non-zero segment (^6.0.2 -> 7, ^0.2.3 -> 0.3)
+func caretUpperBound(v Version) Version {
+	for i, segment := range v.Segments {
+		if segment != 0 || i == len(v.Segments)-1 {
+			segments := append([]int{}, v.Segments[:i+1]...)
+			segments[i]++
+			return Version{Segments: segments}
+		}
+	}
+	return Version{Segments: []int{1}}
+}
+
+// core strips the prerelease and build suffixes from a version
+func core(v Version) Version {
+	return Version{Segments: v.Segments}
+}
+
+func isWildcard(s string) bool {
+	return s == "*" || s == "x" || s == "X"
+}
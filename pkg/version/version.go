@@ -0,0 +1,182 @@
+// ABOUTME: Parses and compares dotted version strings used by tiles and stemcells.
+// ABOUTME: Provides ordering and constraint helpers shared by comparison and compatibility checks.
+package version
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Version represents a parsed dotted version such as 1.500 or 10.2.5-build.2
+type Version struct {
+	Original   string
+	Segments   []int
+	Prerelease string
+	Build      string
+}
+
+// Parse parses a dotted numeric version with optional -prerelease and +build suffixes
+func Parse(s string) (Version, error) {
+	v := Version{Original: s}
+
+	rest := strings.TrimPrefix(strings.TrimSpace(s), "v")
+	if idx := strings.Index(rest, "+"); idx >= 0 {
+		v.Build = rest[idx+1:]
+		rest = rest[:idx]
+	}
+	if idx := strings.Index(rest, "-"); idx >= 0 {
+		v.Prerelease = rest[idx+1:]
+		rest = rest[:idx]
+	}
+
+	if rest == "" {
+		return Version{}, fmt.Errorf("invalid version %q", s)
+	}
+
+	for _, part := range strings.Split(rest, ".") {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return Version{}, fmt.Errorf("invalid version %q", s)
+		}
+		v.Segments = append(v.Segments, n)
+	}
+
+	return v, nil
+}
+
+// Major returns the first version segment
+func (v Version) Major() int {
+	return v.segment(0)
+}
+
+// Minor returns the second version segment (0 if absent)
+func (v Version) Minor() int {
+	return v.segment(1)
+}
+
+// Patch returns the third version segment (0 if absent)
+func (v Version) Patch() int {
+	return v.segment(2)
+}
+
+// Compare returns -1, 0, or 1 if v is lower than, equal to, or higher than other.
+// Missing segments are treated as zero and a prerelease sorts before its release.
+func (v Version) Compare(other Version) int {
+	length := len(v.Segments)
+	if len(other.Segments) > length {
+		length = len(other.Segments)
+	}
+
+	for i := 0; i < length; i++ {
+		a, b := v.segment(i), other.segment(i)
+		if a < b {
+			return -1
+		}
+		if a > b {
+			return 1
+		}
+	}
+
+	switch {
+	case v.Prerelease == other.Prerelease:
+		return 0
+	case v.Prerelease == "":
+		return 1
+	case other.Prerelease == "":
+		return -1
+	default:
+		return comparePrerelease(v.Prerelease, other.Prerelease)
+	}
+}
+
+// comparePrerelease orders dot-separated prerelease identifiers as semver does, comparing numeric
+// identifiers numerically so build.12 sorts after build.2
+func comparePrerelease(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		an, aErr := strconv.Atoi(as[i])
+		bn, bErr := strconv.Atoi(bs[i])
+		switch {
+		case aErr == nil && bErr == nil && an != bn:
+			if an < bn {
+				return -1
+			}
+			return 1
+		case aErr == nil && bErr != nil:
+			return -1
+		case aErr != nil && bErr == nil:
+			return 1
+		case as[i] != bs[i]:
+			if as[i] < bs[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+
+	switch {
+	case len(as) < len(bs):
+		return -1
+	case len(as) > len(bs):
+		return 1
+	default:
+		return 0
+	}
+}
+
+// IsLTS reports whether the version carries a Pivnet long-term support suffix such as +LTS-T
+func (v Version) IsLTS() bool {
+	return strings.Contains(strings.ToUpper(v.Build), "LTS")
+}
+
+// Compare parses and compares two version strings
+func Compare(a, b string) (int, error) {
+	va, err := Parse(a)
+	if err != nil {
+		return 0, err
+	}
+	vb, err := Parse(b)
+	if err != nil {
+		return 0, err
+	}
+	return va.Compare(vb), nil
+}
+
+func (v Version) segment(i int) int {
+	if i < len(v.Segments) {
+		return v.Segments[i]
+	}
+	return 0
+}
+
+// Satisfies reports whether version meets a constraint expression such as
+// "~> 2.10", ">= 1.2.0" or ">= 2.0, < 3", using the grammar of ParseRange.
+// Build suffixes like -build.1 on the version do not affect the result. A bare
+// full version ("1.2.3") requires that release; a bare partial version ("2.10")
+// accepts any release in its line.
+func Satisfies(v, constraint string) (bool, error) {
+	parsed, err := Parse(v)
+	if err != nil {
+		return false, err
+	}
+
+	r, err := ParseRange(constraint)
+	if err != nil {
+		return false, err
+	}
+	return r.Contains(parsed), nil
+}
+
+// pessimisticUpperBound returns the exclusive upper bound for a "~>" constraint:
+// ~> 2.10 allows < 3.0 and ~> 2.10.1 allows < 2.11
+func pessimisticUpperBound(target Version) Version {
+	segments := append([]int{}, target.Segments...)
+	if len(segments) == 1 {
+		return Version{Segments: []int{segments[0] + 1}}
+	}
+
+	segments = segments[:len(segments)-1]
+	segments[len(segments)-1]++
+	return Version{Segments: segments}
+}
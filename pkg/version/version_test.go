@@ -0,0 +1,186 @@
+// ABOUTME: Unit tests for version parsing and ordering.
+// ABOUTME: Validates segment handling, suffixes, and comparison results.
+package version
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	v, err := Parse("10.2.5-build.2")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if v.Major() != 10 || v.Minor() != 2 || v.Patch() != 5 {
+		t.Errorf("Expected 10.2.5, got %v", v.Segments)
+	}
+	if v.Prerelease != "build.2" {
+		t.Errorf("Expected prerelease 'build.2', got '%s'", v.Prerelease)
+	}
+
+	v, err = Parse("6.0.22+LTS-T")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if v.Build != "LTS-T" {
+		t.Errorf("Expected build 'LTS-T', got '%s'", v.Build)
+	}
+	if v.Prerelease != "" {
+		t.Errorf("Expected no prerelease, got '%s'", v.Prerelease)
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, input := range []string{"", "abc", "1..2", "1.x"} {
+		if _, err := Parse(input); err == nil {
+			t.Errorf("Expected error for %q", input)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.500", "1.456", 1},
+		{"1.456", "1.500", -1},
+		{"1.500", "1.500.0", 0},
+		{"6.0.2", "6.0.20", -1},
+		{"10.2.5", "6.0.22", 1},
+		{"10.2.5-build.2", "10.2.5", -1},
+		{"3.3.0-build.12", "3.3.0-build.2", 1},
+		{"3.3.0-build.2", "3.3.0-build.2.1", -1},
+		{"1.0.0-rc.1", "1.0.0-build.1", 1},
+	}
+
+	for _, tt := range tests {
+		got, err := Compare(tt.a, tt.b)
+		if err != nil {
+			t.Fatalf("Compare(%s, %s) failed: %v", tt.a, tt.b, err)
+		}
+		if got != tt.want {
+			t.Errorf("Compare(%s, %s) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestSatisfies(t *testing.T) {
+	tests := []struct {
+		version    string
+		constraint string
+		want       bool
+	}{
+		{"3.0.25-build.1", ">= 3.0.0", true},
+		{"2.10.60", ">= 3.0.0", false},
+		{"2.10.60", "~> 2.10", true},
+		{"3.0.1", "~> 2.10", false},
+		{"2.10.5", "~> 2.10.1", true},
+		{"2.11.0", "~> 2.10.1", false},
+		{"10.2.5", ">= 10.0, < 11", true},
+		{"11.0.0", ">= 10.0, < 11", false},
+		{"1.2.3", "1.2.3", true},
+		{"1.2.4", "1.2.3", false},
+		{"1.2.4", "!= 1.2.3", true},
+		{"2.10.5", "2.10", true},
+		{"2.10.5", "<= 2.10", true},
+		{"2.11.0", "<= 2.10", false},
+	}
+
+	for _, tt := range tests {
+		got, err := Satisfies(tt.version, tt.constraint)
+		if err != nil {
+			t.Fatalf("Satisfies(%s, %s) failed: %v", tt.version, tt.constraint, err)
+		}
+		if got != tt.want {
+			t.Errorf("Satisfies(%s, %q) = %v, want %v", tt.version, tt.constraint, got, tt.want)
+		}
+	}
+}
+
+func TestSatisfiesInvalidConstraint(t *testing.T) {
+	if _, err := Satisfies("1.0.0", ">= abc"); err == nil {
+		t.Error("Expected error for invalid constraint")
+	}
+}
+
+func TestIsLTS(t *testing.T) {
+	for input, want := range map[string]bool{"6.0.22+LTS-T": true, "10.2.5+lts": true, "10.3.0": false, "3.3.0-build.12": false} {
+		v, _ := Parse(input)
+		if v.IsLTS() != want {
+			t.Errorf("IsLTS(%s) = %v, want %v", input, v.IsLTS(), want)
+		}
+	}
+}
+
+func TestParseRange(t *testing.T) {
+	versions := []string{"6.0.2", "6.0.20+LTS-T", "6.0.22+LTS-T", "6.1.0", "10.2.4+LTS-T", "10.2.5+LTS-T", "10.3.0-build.2", "11.0.0"}
+
+	tests := []struct {
+		expr string
+		want []string
+	}{
+		{"6.0.2", []string{"6.0.2"}},
+		{"6.0.22", []string{"6.0.22+LTS-T"}},
+		{"6.0.22+LTS-T", []string{"6.0.22+LTS-T"}},
+		{"6.0", []string{"6.0.2", "6.0.20+LTS-T", "6.0.22+LTS-T"}},
+		{"10.x", []string{"10.2.4+LTS-T", "10.2.5+LTS-T", "10.3.0-build.2"}},
+		{"~10.2", []string{"10.2.4+LTS-T", "10.2.5+LTS-T"}},
+		{"~10.2.5", []string{"10.2.5+LTS-T"}},
+		{"^10.2", []string{"10.2.4+LTS-T", "10.2.5+LTS-T", "10.3.0-build.2"}},
+		{"~> 6.0.20", []string{"6.0.20+LTS-T", "6.0.22+LTS-T"}},
+		{">=6.0.20 <6.1", []string{"6.0.20+LTS-T", "6.0.22+LTS-T"}},
+		{">= 6.0.20, < 6.1", []string{"6.0.20+LTS-T", "6.0.22+LTS-T"}},
+		{">6.0", []string{"6.1.0", "10.2.4+LTS-T", "10.2.5+LTS-T", "10.3.0-build.2", "11.0.0"}},
+		{"<=10.2 >6.0.22", []string{"6.1.0", "10.2.4+LTS-T", "10.2.5+LTS-T"}},
+		{"6.1 || >=11", []string{"6.1.0", "11.0.0"}},
+		{"~10.2 !=10.2.4", []string{"10.2.5+LTS-T"}},
+		{"*", versions},
+	}
+
+	for _, tt := range tests {
+		r, err := ParseRange(tt.expr)
+		if err != nil {
+			t.Errorf("ParseRange(%q) failed: %v", tt.expr, err)
+			continue
+		}
+
+		var got []string
+		for _, input := range versions {
+			v, _ := Parse(input)
+			if r.Contains(v) {
+				got = append(got, input)
+			}
+		}
+		if strings.Join(got, " ") != strings.Join(tt.want, " ") {
+			t.Errorf("ParseRange(%q) matched %v, want %v", tt.expr, got, tt.want)
+		}
+	}
+}
+
+func TestParseRangeInvalid(t *testing.T) {
+	for _, expr := range []string{"latest", ">=", "~abc", "6.0.x.1", "<*"} {
+		if _, err := ParseRange(expr); err == nil {
+			t.Errorf("Expected error for %q", expr)
+		}
+	}
+}
+
+func TestRangeNamesPrerelease(t *testing.T) {
+	for expr, want := range map[string]bool{
+		"":                         false,
+		"~10.2":                    false,
+		"10.3.0-build.2":           true,
+		">=10.3.0-build.1 <11":     true,
+		"6.0 || ~> 10.3.0-build.1": true,
+	} {
+		r, err := ParseRange(expr)
+		if err != nil {
+			t.Fatalf("ParseRange(%q) failed: %v", expr, err)
+		}
+		if r.NamesPrerelease() != want {
+			t.Errorf("ParseRange(%q).NamesPrerelease() = %v, want %v", expr, r.NamesPrerelease(), want)
+		}
+	}
+}
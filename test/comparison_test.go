@@ -88,8 +88,17 @@ func TestActionableReportGeneration(t *testing.T) {
 	categorized := report.CategorizeChanges(filtered)
 
 	// Generate reports
-	textReport := report.GenerateTextReport(categorized, "6.0.22", "10.2.5")
-	jsonReport := report.GenerateJSONReport(categorized, "6.0.22", "10.2.5")
+	oldInfo, err := report.NewTileInfo(oldTilePath, oldMetadata, "")
+	if err != nil {
+		t.Fatalf("Failed to describe old tile: %v", err)
+	}
+	newInfo, err := report.NewTileInfo(newTilePath, newMetadata, "")
+	if err != nil {
+		t.Fatalf("Failed to describe new tile: %v", err)
+	}
+	header := report.ReportHeader{Old: oldInfo, New: newInfo}
+	textReport := report.GenerateTextReport(categorized, header)
+	jsonReport := report.GenerateJSONReport(categorized, header)
 
 	// Verify text report
 	if !strings.Contains(textReport, "Upgrade Analysis") {
@@ -8,14 +8,14 @@ import (
 )
 
 func TestReleaseNotesEnrichment_EndToEnd(t *testing.T) {
-	// Load test config
-	config, err := releasenotes.LoadProductConfig("../pkg/releasenotes/testdata/products.yaml")
+	// Load test registry
+	registry, err := releasenotes.LoadRegistry("../pkg/releasenotes/testdata/products.yaml")
 	if err != nil {
-		t.Fatalf("Failed to load config: %v", err)
+		t.Fatalf("Failed to load registry: %v", err)
 	}
 
 	// Resolve URL
-	url, err := config.ResolveURL("cf", "10.2.5")
+	url, err := registry.ResolveURL("cf", "10.2.5")
 	if err != nil {
 		t.Fatalf("Failed to resolve URL: %v", err)
 	}